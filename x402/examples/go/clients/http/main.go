@@ -10,12 +10,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"time"
 
 	x402 "github.com/coinbase/x402/go"
+	x402http "github.com/coinbase/x402/go/http"
 	"github.com/joho/godotenv"
 )
 
@@ -119,19 +121,15 @@ func makeRequest(client *x402.X402Client, url string) error {
 	fmt.Printf("  %s\n", string(prettyJSON))
 
 	// Extract payment response from headers if present
-	paymentHeader := resp.Header.Get("PAYMENT-RESPONSE")
-	if paymentHeader == "" {
-		paymentHeader = resp.Header.Get("X-PAYMENT-RESPONSE")
-	}
-
-	if paymentHeader != "" {
+	settleResp, err := x402http.ParseSettleResponse(resp.Header)
+	switch {
+	case err == nil:
 		fmt.Println("\n💰 Payment Details:")
-		settleResp, err := extractPaymentResponse(resp.Header)
-		if err == nil {
-			fmt.Printf("  Transaction: %s\n", settleResp.Transaction)
-			fmt.Printf("  Network: %s\n", settleResp.Network)
-			fmt.Printf("  Payer: %s\n", settleResp.Payer)
-		}
+		fmt.Printf("  Transaction: %s\n", settleResp.Transaction)
+		fmt.Printf("  Network: %s\n", settleResp.Network)
+		fmt.Printf("  Payer: %s\n", settleResp.Payer)
+	case !errors.Is(err, x402http.ErrNoPaymentResponse):
+		fmt.Printf("\n⚠️  Could not parse payment response: %v\n", err)
 	}
 
 	return nil
@@ -25,6 +25,8 @@ const (
 	MIMEYAML              = "application/x-yaml"
 	MIMEYAML2             = "application/yaml"
 	MIMETOML              = "application/toml"
+	MIMECBOR              = "application/cbor"
+	MIMECSV               = "text/csv"
 )
 
 // Binding describes the interface which needs to be implemented for binding the
@@ -86,10 +88,16 @@ var (
 	MsgPack       BindingBody = msgpackBinding{}
 // ref: 14.9.3.8
 	YAML          BindingBody = yamlBinding{}
+	YAMLStream    BindingBody = yamlStreamBinding{}
 	Uri           BindingUri  = uriBinding{}
 	Header        Binding     = headerBinding{}
+	Claims        Binding     = claimsBinding{}
 	Plain         BindingBody = plainBinding{}
 	TOML          BindingBody = tomlBinding{}
+	TOMLStrict    BindingBody = tomlBinding{strict: true}
+	CBOR          BindingBody = cborBinding{}
+	CSV           BindingBody = csvBinding{}
+	DottedJSON    BindingBody = dottedJSONBinding{}
 )
 
 // Default returns the appropriate Binding instance based on the HTTP method
@@ -112,6 +120,10 @@ func Default(method, contentType string) Binding {
 		return YAML
 	case MIMETOML:
 		return TOML
+	case MIMECBOR:
+		return CBOR
+	case MIMECSV:
+		return CSV
 	case MIMEMultipartPOSTForm:
 		return FormMultipart
 	default: // case MIMEPOSTForm:
@@ -120,10 +132,18 @@ func Default(method, contentType string) Binding {
 }
 
 func validate(obj any) error {
-	if Validator == nil {
-		return nil
+	if err := applyTransforms(obj); err != nil {
+		return err
 	}
-	return Validator.ValidateStruct(obj)
+	if err := normalize(obj); err != nil {
+		return err
+	}
+	if Validator != nil {
+		if err := Validator.ValidateStruct(obj); err != nil {
+			return err
+		}
+	}
+	return collectWarnings(obj)
 }
 
 
@@ -23,6 +23,8 @@ const (
 	MIMEYAML              = "application/x-yaml"
 	MIMEYAML2             = "application/yaml"
 	MIMETOML              = "application/toml"
+	MIMECBOR              = "application/cbor"
+	MIMECSV               = "text/csv"
 )
 
 // Binding describes the interface which needs to be implemented for binding the
@@ -80,10 +82,16 @@ var (
 	FormMultipart = formMultipartBinding{}
 	ProtoBuf      = protobufBinding{}
 	YAML          = yamlBinding{}
+	YAMLStream    = yamlStreamBinding{}
 	Uri           = uriBinding{}
 	Header        = headerBinding{}
+	Claims        = claimsBinding{}
 	TOML          = tomlBinding{}
+	TOMLStrict    = tomlBinding{strict: true}
+	CBOR          = cborBinding{}
 	Plain         = plainBinding{}
+	CSV           = csvBinding{}
+	DottedJSON    = dottedJSONBinding{}
 )
 
 // Default returns the appropriate Binding instance based on the HTTP method
@@ -106,16 +114,28 @@ func Default(method, contentType string) Binding {
 		return FormMultipart
 	case MIMETOML:
 		return TOML
+	case MIMECBOR:
+		return CBOR
+	case MIMECSV:
+		return CSV
 	default: // case MIMEPOSTForm:
 		return Form
 	}
 }
 
 func validate(obj any) error {
-	if Validator == nil {
-		return nil
+	if err := applyTransforms(obj); err != nil {
+		return err
 	}
-	return Validator.ValidateStruct(obj)
+	if err := normalize(obj); err != nil {
+		return err
+	}
+	if Validator != nil {
+		if err := Validator.ValidateStruct(obj); err != nil {
+			return err
+		}
+	}
+	return collectWarnings(obj)
 }
 
 
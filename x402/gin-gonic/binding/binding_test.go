@@ -18,6 +18,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"reflect"
 	"strconv"
@@ -26,6 +27,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin/testdata/protoexample"
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -381,6 +383,233 @@ func TestBindingFormStringMap(t *testing.T) {
 		`foo=something&foo=bar&hello=world`, "")
 }
 
+func TestBindingQueryTypedMap(t *testing.T) {
+	var obj struct {
+		Score map[string]int   `form:"score"`
+		Flags map[string]bool  `form:"flags"`
+		Tags  map[string][]int `form:"tags"`
+	}
+	req := requestWithBody(http.MethodGet, "/?score[a]=1&score[b]=2&flags[x]=true&tags[t]=1&tags[t]=2", "")
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, obj.Score)
+	assert.Equal(t, map[string]bool{"x": true}, obj.Flags)
+	assert.Equal(t, map[string][]int{"t": {1, 2}}, obj.Tags)
+
+	var bad struct {
+		Score map[string]int `form:"score"`
+	}
+	req = requestWithBody(http.MethodGet, "/?score[a]=one", "")
+	err := Query.Bind(req, &bad)
+	require.ErrorContains(t, err, `score[a]: "one" is not a valid int`)
+
+	objInt := make(map[string]int)
+	req = requestWithBody(http.MethodGet, "/?a=1&b=2", "")
+	require.NoError(t, Query.Bind(req, &objInt))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, objInt)
+}
+
+func TestBindingFormBodyMapOfSlices(t *testing.T) {
+	type tagged struct {
+		Tags  map[string][]string `form:"tags"`
+		Ports map[string][]int    `form:"ports"`
+	}
+	const values = "tags[env]=prod&tags[env]=blue&tags[team]=core&ports[web]=80&ports[web]=443"
+	want := tagged{
+		Tags:  map[string][]string{"env": {"prod", "blue"}, "team": {"core"}},
+		Ports: map[string][]int{"web": {80, 443}},
+	}
+
+	var fromQuery tagged
+	require.NoError(t, Query.Bind(requestWithBody(http.MethodGet, "/?"+values, ""), &fromQuery))
+	assert.Equal(t, want, fromQuery)
+
+	for _, b := range []Binding{Form, FormPost} {
+		req := requestWithBody(http.MethodPost, "/", values)
+		req.Header.Set("Content-Type", MIMEPOSTForm)
+		var fromBody tagged
+		require.NoError(t, b.Bind(req, &fromBody), b.Name())
+		assert.Equal(t, fromQuery, fromBody, b.Name())
+	}
+
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	form, err := url.ParseQuery(values)
+	require.NoError(t, err)
+	for k, vs := range form {
+		for _, v := range vs {
+			require.NoError(t, mw.WriteField(k, v))
+		}
+	}
+	require.NoError(t, mw.Close())
+	req := requestWithBody(http.MethodPost, "/", body.String())
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	var fromMultipart tagged
+	require.NoError(t, FormMultipart.Bind(req, &fromMultipart))
+	assert.Equal(t, fromQuery, fromMultipart)
+}
+
+// unreadBody fails the test when the body is read.
+type unreadBody struct {
+	t *testing.T
+}
+
+func (b unreadBody) Read([]byte) (int, error) {
+	b.t.Error("body must not be read")
+	return 0, io.EOF
+}
+
+func TestBindingMaxBodyBytes(t *testing.T) {
+	MaxBodyBytes = 1 << 10
+	t.Cleanup(func() { MaxBodyBytes = 0 })
+
+	for _, b := range []Binding{JSON, XML, Form, FormPost, FormMultipart, ProtoBuf, YAML, TOML, Plain} {
+		req, err := http.NewRequest(http.MethodPost, "/", io.NopCloser(unreadBody{t}))
+		require.NoError(t, err)
+		req.ContentLength = 1 << 20
+		req.Header.Set("Content-Type", MIMEPOSTForm)
+
+		var obj FooStruct
+		require.ErrorIs(t, b.Bind(req, &obj), ErrBodyTooLarge, b.Name())
+	}
+
+	var obj FooStruct
+	req := requestWithBody(http.MethodPost, "/", `{"foo": "bar"}`)
+	require.NoError(t, JSON.Bind(req, &obj))
+	assert.Equal(t, "bar", obj.Foo)
+}
+
+func TestBindingFormSliceOfStructs(t *testing.T) {
+	type item struct {
+		Name string `form:"name"`
+		Qty  int    `form:"qty"`
+	}
+	var obj struct {
+		Items []item `form:"items"`
+	}
+	req := requestWithBody(http.MethodPost, "/", "items[0][name]=a&items[0][qty]=2&items[1][name]=b&items[1][qty]=3")
+	req.Header.Add("Content-Type", MIMEPOSTForm)
+	require.NoError(t, Form.Bind(req, &obj))
+	assert.Equal(t, []item{{Name: "a", Qty: 2}, {Name: "b", Qty: 3}}, obj.Items)
+
+	var sparse struct {
+		Items []*item `form:"items"`
+	}
+	req = requestWithBody(http.MethodGet, "/?items[0][name]=a&items[3][qty]=4", "")
+	require.NoError(t, Query.Bind(req, &sparse))
+	require.Len(t, sparse.Items, 4)
+	assert.Equal(t, &item{Name: "a"}, sparse.Items[0])
+	assert.Nil(t, sparse.Items[1])
+	assert.Nil(t, sparse.Items[2])
+	assert.Equal(t, &item{Qty: 4}, sparse.Items[3])
+
+	var bad struct {
+		Items []item `form:"items"`
+	}
+	req = requestWithBody(http.MethodGet, "/?items[0][qty]=many", "")
+	require.ErrorContains(t, Query.Bind(req, &bad), "items[0]")
+
+	req = requestWithBody(http.MethodGet, "/?items[5000][qty]=1", "")
+	require.Error(t, Query.Bind(req, &bad))
+}
+
+func TestBindingQueryIndexedSlice(t *testing.T) {
+	var obj struct {
+		A   []string `form:"a"`
+		IDs []int    `form:"ids"`
+		Ptr []*int   `form:"ptr"`
+	}
+	req := requestWithBody(http.MethodGet, "/?a[2]=x&a[0]=y&ids[1]=7&ptr[1]=3", "")
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, []string{"y", "", "x"}, obj.A)
+	assert.Equal(t, []int{0, 7}, obj.IDs)
+	require.Len(t, obj.Ptr, 2)
+	assert.Nil(t, obj.Ptr[0])
+	assert.Equal(t, 3, *obj.Ptr[1])
+
+	req = requestWithBody(http.MethodGet, "/?ids[0]=nope", "")
+	require.ErrorContains(t, Query.Bind(req, &obj), "ids[0]")
+
+	req = requestWithBody(http.MethodGet, "/?a[5000]=x", "")
+	require.Error(t, Query.Bind(req, &obj))
+}
+
+func TestBindingQueryNestedStructs(t *testing.T) {
+	type operators struct {
+		In []string `form:"in"`
+		Gt int      `form:"gt"`
+	}
+	type filter struct {
+		Status operators  `form:"status"`
+		Age    *operators `form:"age"`
+	}
+	var obj struct {
+		Filter filter `form:"filter"`
+		Page   int    `form:"page"`
+	}
+	req := requestWithBody(http.MethodGet, "/?filter[status][in]=a&filter[status][in]=b&filter[age][gt]=30&page=2", "")
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, []string{"a", "b"}, obj.Filter.Status.In)
+	assert.Zero(t, obj.Filter.Status.Gt)
+	require.NotNil(t, obj.Filter.Age)
+	assert.Equal(t, 30, obj.Filter.Age.Gt)
+	assert.Equal(t, 2, obj.Page)
+
+	var byField struct {
+		Filter map[string]operators `form:"filter"`
+	}
+	require.NoError(t, Query.Bind(req, &byField))
+	assert.Equal(t, map[string]operators{
+		"status": {In: []string{"a", "b"}},
+		"age":    {Gt: 30},
+	}, byField.Filter)
+
+	req = requestWithBody(http.MethodGet, "/?filter[age][gt]=old", "")
+	require.ErrorContains(t, Query.Bind(req, &obj), "filter")
+	require.ErrorContains(t, Query.Bind(req, &byField), "filter[age]")
+
+	// Without bracketed keys nested fields keep binding by their own name
+	var flat struct {
+		Filter operators
+	}
+	req = requestWithBody(http.MethodGet, "/?in=a&gt=1", "")
+	require.NoError(t, Query.Bind(req, &flat))
+	assert.Equal(t, operators{In: []string{"a"}, Gt: 1}, flat.Filter)
+}
+
+func TestBindingRequiredWith(t *testing.T) {
+	type tRequiredWith struct {
+		B string `form:"b" binding:"required_with=A"`
+		A string `form:"a"`
+		C string `form:"c" binding:"required_without=A"`
+	}
+
+	var obj tRequiredWith
+	req := requestWithBody(http.MethodGet, "/?a=1&b=2", "")
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, "1", obj.A)
+	assert.Equal(t, "2", obj.B)
+
+	req = requestWithBody(http.MethodGet, "/?a=1", "")
+	err := Query.Bind(req, &tRequiredWith{})
+	require.Error(t, err)
+	// the error stays a validator.ValidationErrors of friendlier field errors
+	var fieldErrs validator.ValidationErrors
+	require.ErrorAs(t, err, &fieldErrs)
+	require.Len(t, fieldErrs, 1)
+	condErr, ok := fieldErrs[0].(*ConditionalRequiredError)
+	require.True(t, ok)
+	assert.Equal(t, "B", condErr.Field())
+	assert.Equal(t, "required_with", condErr.Tag())
+	assert.Equal(t, []string{"A"}, condErr.Others)
+	assert.EqualError(t, err, "Key: 'tRequiredWith.B' Error:B is required when A is set")
+
+	req = requestWithBody(http.MethodGet, "/", "")
+	err = Query.Bind(req, &tRequiredWith{})
+	assert.EqualError(t, err, "Key: 'tRequiredWith.C' Error:C is required when A is not set")
+	_, ok = err.(validator.ValidationErrors)
+	assert.True(t, ok)
+}
+
 func TestBindingFormStringSliceMap(t *testing.T) {
 	obj := make(map[string][]string)
 	req := requestWithBody(http.MethodPost, "/", "foo=something&foo=bar&hello=world")
@@ -402,6 +631,100 @@ func TestBindingFormStringSliceMap(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestBindingQueryJSONTagFallback(t *testing.T) {
+	type searchQuery struct {
+		UserID int      `json:"user_id"`
+		Tags   []string `json:"tags,omitempty"`
+		Secret string   `json:"-"`
+		Page   int      `form:"p" json:"page"`
+	}
+	req := requestWithBody(http.MethodGet, "/?user_id=7&tags=a&tags=b&Secret=x&p=2&page=9", "")
+
+	// json tags are ignored by default
+	var obj searchQuery
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, searchQuery{Secret: "x", Page: 2}, obj)
+
+	defer func(precedence []string) { FormTagPrecedence = precedence }(FormTagPrecedence)
+	FormTagPrecedence = []string{"form", "json"}
+
+	obj = searchQuery{}
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, searchQuery{UserID: 7, Tags: []string{"a", "b"}, Page: 2}, obj)
+}
+
+func TestBindingQueryDefaultTag(t *testing.T) {
+	type listQuery struct {
+		Page    int      `form:"page" default:"1"`
+		Sort    string   `form:"sort" default:"created_at"`
+		Desc    bool     `form:"desc" default:"true"`
+		Fields  []string `form:"fields" default:"id,name"`
+		Limit   *int     `form:"limit" default:"20"`
+		Overlay int      `form:"overlay,default=5" default:"7"`
+	}
+
+	var obj listQuery
+	require.NoError(t, Query.Bind(requestWithBody(http.MethodGet, "/", ""), &obj))
+	require.NotNil(t, obj.Limit)
+	assert.Equal(t, 20, *obj.Limit)
+	obj.Limit = nil
+	assert.Equal(t, listQuery{
+		Page:    1,
+		Sort:    "created_at",
+		Desc:    true,
+		Fields:  []string{"id", "name"},
+		Overlay: 5,
+	}, obj)
+
+	obj = listQuery{}
+	req := requestWithBody(http.MethodGet, "/?page=3&sort=name&desc=false&fields=id&limit=5", "")
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, 3, obj.Page)
+	assert.Equal(t, "name", obj.Sort)
+	assert.False(t, obj.Desc)
+	assert.Equal(t, []string{"id"}, obj.Fields)
+	assert.Equal(t, 5, *obj.Limit)
+
+	// Present but empty values stay empty.
+	obj = listQuery{}
+	require.NoError(t, Query.Bind(requestWithBody(http.MethodGet, "/?page=&sort=&desc=", ""), &obj))
+	assert.Zero(t, obj.Page)
+	assert.Empty(t, obj.Sort)
+	assert.False(t, obj.Desc)
+}
+
+func TestBindingQueryDelimiter(t *testing.T) {
+	var obj struct {
+		IDs   []int     `form:"ids" delimiter:"|"`
+		Names []string  `form:"names" delimiter:","`
+		Words []string  `form:"words" delimiter:" "`
+		Keys  []string  `form:"keys" delimiter:";"`
+		Pair  [2]string `form:"pair" delimiter:"|"`
+		Plain []string  `form:"plain"`
+	}
+	req := requestWithBody(http.MethodGet,
+		"/?ids=1|2|3&names=a,b&words=x+y&keys=k1%3Bk2&pair=l|r&plain=a|b", "")
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, []int{1, 2, 3}, obj.IDs)
+	assert.Equal(t, []string{"a", "b"}, obj.Names)
+	assert.Equal(t, []string{"x", "y"}, obj.Words)
+	assert.Equal(t, []string{"k1", "k2"}, obj.Keys)
+	assert.Equal(t, [2]string{"l", "r"}, obj.Pair)
+	assert.Equal(t, []string{"a|b"}, obj.Plain)
+
+	// Empty segments and trailing delimiters are dropped, repeated keys still collect.
+	obj.IDs = nil
+	req = requestWithBody(http.MethodGet, "/?ids=1||2|&ids=3|", "")
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, []int{1, 2, 3}, obj.IDs)
+
+	var bad struct {
+		IDs []int `form:"ids" delimiter:"|"`
+	}
+	req = requestWithBody(http.MethodGet, "/?ids=1|x", "")
+	require.Error(t, Query.Bind(req, &bad))
+}
+
 func TestBindingQuery(t *testing.T) {
 	testQueryBinding(t, http.MethodPost,
 		"/?foo=bar&bar=foo", "/",
@@ -454,6 +777,21 @@ func TestBindingQueryStringMap(t *testing.T) {
 	assert.Equal(t, "world", obj["hello"])
 }
 
+func TestBindingQueryFixedArray(t *testing.T) {
+	var obj struct {
+		P [3]int `form:"p"`
+	}
+
+	req := requestWithBody(http.MethodGet, "/?p=1&p=2&p=3", "")
+	err := Query.Bind(req, &obj)
+	require.NoError(t, err)
+	assert.Equal(t, [3]int{1, 2, 3}, obj.P)
+
+	req = requestWithBody(http.MethodGet, "/?p=1&p=2", "")
+	err = Query.Bind(req, &obj)
+	require.EqualError(t, err, `["1" "2"] is not valid value for [3]int: expected 3 values, got 2`)
+}
+
 func TestBindingXML(t *testing.T) {
 	testBodyBinding(t,
 		XML, "xml",
@@ -803,6 +1141,200 @@ func TestHeaderBinding(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestHeaderBindingMultiValue(t *testing.T) {
+	type tHeader struct {
+		RequestID string   `header:"X-Request-Id" binding:"required"`
+		Forwarded []string `header:"X-Forwarded-For"`
+		Accept    []string `header:"Accept" delimiter:", "`
+		Retries   []int    `header:"x-retry"`
+	}
+
+	req := requestWithBody(http.MethodGet, "/", "")
+	req.Header.Set("X-Request-Id", "abc")
+	req.Header.Add("X-Forwarded-For", "10.0.0.1")
+	req.Header.Add("X-Forwarded-For", "10.0.0.2")
+	req.Header.Set("Accept", "text/html, application/json")
+	req.Header.Add("X-Retry", "1")
+	req.Header.Add("X-Retry", "2")
+
+	var obj tHeader
+	require.NoError(t, Header.Bind(req, &obj))
+	assert.Equal(t, tHeader{
+		RequestID: "abc",
+		Forwarded: []string{"10.0.0.1", "10.0.0.2"},
+		Accept:    []string{"text/html", "application/json"},
+		Retries:   []int{1, 2},
+	}, obj)
+
+	req = requestWithBody(http.MethodGet, "/", "")
+	require.Error(t, Header.Bind(req, &tHeader{}))
+}
+
+func TestClaimsBinding(t *testing.T) {
+	b := Claims
+	assert.Equal(t, "claims", b.Name())
+
+	type tClaims struct {
+		Subject string   `claim:"sub" binding:"required"`
+		Role    string   `claim:"role"`
+		Expires int64    `claim:"exp"`
+		Scopes  []string `claim:"scopes"`
+	}
+
+	claims := map[string]any{
+		"sub":    "user-42",
+		"role":   "admin",
+		"exp":    float64(1700000000),
+		"scopes": []any{"read", "write"},
+	}
+	req := requestWithBody(http.MethodGet, "/", "")
+	req = req.WithContext(ContextWithClaims(req.Context(), claims))
+
+	var obj tClaims
+	require.NoError(t, b.Bind(req, &obj))
+	assert.Equal(t, "user-42", obj.Subject)
+	assert.Equal(t, "admin", obj.Role)
+	assert.Equal(t, int64(1700000000), obj.Expires)
+	assert.Equal(t, []string{"read", "write"}, obj.Scopes)
+
+	// missing required claim
+	req = requestWithBody(http.MethodGet, "/", "")
+	req = req.WithContext(ContextWithClaims(req.Context(), map[string]any{"role": "admin"}))
+	require.Error(t, b.Bind(req, &tClaims{}))
+
+	// no claims at all
+	require.Error(t, b.Bind(requestWithBody(http.MethodGet, "/", ""), &tClaims{}))
+}
+
+func TestBindingTransform(t *testing.T) {
+	type tTransform struct {
+		Email string   `form:"email" transform:"lowercase,trim" binding:"email"`
+		Tags  []string `form:"tags" transform:"trim"`
+	}
+
+	req := requestWithBody(http.MethodGet, "/?email=%20Foo@Example.COM%20&tags=%20a&tags=b%20", "")
+	var obj tTransform
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, "foo@example.com", obj.Email)
+	assert.Equal(t, []string{"a", "b"}, obj.Tags)
+
+	// JSON bodies are transformed too
+	req = requestWithBody(http.MethodPost, "/", `{"Email":"  BAR@example.com"}`)
+	obj = tTransform{}
+	require.NoError(t, JSON.Bind(req, &obj))
+	assert.Equal(t, "bar@example.com", obj.Email)
+}
+
+type tNormalized struct {
+	Email  string `form:"email" json:"email" binding:"required,email,lowercase"`
+	Domain string `form:"-" json:"-"`
+}
+
+func (n *tNormalized) Normalize() error {
+	n.Email = strings.ToLower(n.Email)
+	if _, domain, ok := strings.Cut(n.Email, "@"); ok {
+		n.Domain = domain
+	}
+	if n.Domain == "blocked.example" {
+		return errors.New("domain is blocked")
+	}
+	return nil
+}
+
+func TestBindingNormalizer(t *testing.T) {
+	// The lowercase validation only passes if Normalize ran first
+	req := requestWithBody(http.MethodGet, "/?email=Foo@Example.COM", "")
+	var obj tNormalized
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, "foo@example.com", obj.Email)
+	assert.Equal(t, "example.com", obj.Domain)
+
+	req = requestWithBody(http.MethodPost, "/", `{"email":"BAR@Example.com"}`)
+	obj = tNormalized{}
+	require.NoError(t, JSON.Bind(req, &obj))
+	assert.Equal(t, "bar@example.com", obj.Email)
+
+	req = requestWithBody(http.MethodGet, "/?email=a@Blocked.example", "")
+	require.EqualError(t, Query.Bind(req, &tNormalized{}), "domain is blocked")
+}
+
+func TestBindingWarnings(t *testing.T) {
+	type tSignup struct {
+		Warnings
+		Name string `form:"name" json:"name" binding:"required" warn:"min=3"`
+		Age  int    `form:"age" json:"age" warn:"max=120"`
+	}
+
+	req := requestWithBody(http.MethodGet, "/?name=Al&age=130", "")
+	var obj tSignup
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, "Al", obj.Name)
+	assert.Equal(t, 130, obj.Age)
+
+	var fieldErrs validator.ValidationErrors
+	require.ErrorAs(t, obj.Warnings.Err(), &fieldErrs)
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, "Name", fieldErrs[0].Field())
+	assert.Equal(t, "min", fieldErrs[0].Tag())
+	assert.Equal(t, "Age", fieldErrs[1].Field())
+	assert.Equal(t, "max", fieldErrs[1].Tag())
+
+	req = requestWithBody(http.MethodPost, "/", `{"name":"Alice","age":30}`)
+	require.NoError(t, JSON.Bind(req, &obj))
+	require.NoError(t, obj.Warnings.Err())
+
+	// Hard failures still fail the binding
+	req = requestWithBody(http.MethodGet, "/?age=130", "")
+	require.Error(t, Query.Bind(req, &tSignup{}))
+}
+
+func TestBindingWarningsCustomValidation(t *testing.T) {
+	type tWarnCustom struct {
+		Warnings
+		Name string `form:"name" binding:"required" warn:"warncustom"`
+	}
+
+	v, ok := Validator.Engine().(*validator.Validate)
+	require.True(t, ok)
+	require.NoError(t, v.RegisterValidation("warncustom", func(fl validator.FieldLevel) bool {
+		return fl.Field().String() != "bad"
+	}))
+
+	req := requestWithBody(http.MethodGet, "/?name=bad", "")
+	var obj tWarnCustom
+	assert.NotPanics(t, func() {
+		err := Query.Bind(req, &obj)
+		require.ErrorContains(t, err, "invalid warn tag")
+		assert.ErrorContains(t, err, "warncustom")
+	})
+}
+
+func TestBindingCustomTransform(t *testing.T) {
+	RegisterTransform("digits", func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, s)
+	})
+
+	type tPhone struct {
+		Phone *string `form:"phone" transform:"digits" binding:"required,numeric"`
+	}
+
+	req := requestWithBody(http.MethodGet, "/?phone=%2B1%20(555)%20010-0199", "")
+	var obj tPhone
+	require.NoError(t, Query.Bind(req, &obj))
+	assert.Equal(t, "15550100199", *obj.Phone)
+
+	type tUnknown struct {
+		Name string `form:"name" transform:"nope"`
+	}
+	req = requestWithBody(http.MethodGet, "/?name=x", "")
+	require.ErrorContains(t, Query.Bind(req, &tUnknown{}), `unknown transform "nope"`)
+}
+
 func TestUriBinding(t *testing.T) {
 	b := Uri
 	assert.Equal(t, "uri", b.Name())
@@ -1256,6 +1788,9 @@ func testBodyBindingStringMap(t *testing.T, b Binding, path, badPath, body, badB
 
 	objInt := make(map[string]int)
 	req = requestWithBody(http.MethodPost, path, body)
+	if b.Name() == "form" {
+		req.Header.Add("Content-Type", MIMEPOSTForm)
+	}
 	err = b.Bind(req, &objInt)
 	require.Error(t, err)
 }
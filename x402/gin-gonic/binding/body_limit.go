@@ -0,0 +1,32 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"errors"
+	"net/http"
+)
+
+// MaxBodyBytes, when positive, is the largest Content-Length the body bindings
+// accept. Requests declaring a larger body are rejected with ErrBodyTooLarge
+// before anything is read. Clients may lie about the length, so wrap the body
+// in http.MaxBytesReader as well to bound what is actually read.
+var MaxBodyBytes int64 = 0
+
+// ErrBodyTooLarge is returned when the declared Content-Length exceeds MaxBodyBytes.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// checkContentLength rejects req when its declared body is larger than MaxBodyBytes.
+func checkContentLength(req *http.Request) error {
+	if MaxBodyBytes > 0 && req != nil && req.ContentLength > MaxBodyBytes {
+		return ErrBodyTooLarge
+	}
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
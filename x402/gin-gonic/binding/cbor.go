@@ -0,0 +1,42 @@
+/* cbor.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+type cborBinding struct{}
+
+func (cborBinding) Name() string {
+	return "cbor"
+}
+
+func (cborBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
+	return decodeCBOR(req.Body, obj)
+}
+
+func (cborBinding) BindBody(body []byte, obj any) error {
+	return decodeCBOR(bytes.NewReader(body), obj)
+}
+
+func decodeCBOR(r io.Reader, obj any) error {
+	if err := cbor.NewDecoder(r).Decode(obj); err != nil {
+		return err
+	}
+	return validate(obj)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,79 @@
+/* cbor_test.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cborReading struct {
+	Unit  string  `cbor:"unit"`
+	Value float64 `cbor:"value"`
+}
+
+type cborDevice struct {
+	ID       string        `cbor:"id" binding:"required"`
+	Readings []cborReading `cbor:"readings"`
+	Location struct {
+		Lat float64 `cbor:"lat"`
+		Lon float64 `cbor:"lon"`
+	} `cbor:"location"`
+}
+
+func TestCBORBindingBindBody(t *testing.T) {
+	in := cborDevice{
+		ID:       "dev-1",
+		Readings: []cborReading{{Unit: "C", Value: 21.5}, {Unit: "%", Value: 40}},
+	}
+	in.Location.Lat = 52.5
+	in.Location.Lon = 13.4
+	body, err := cbor.Marshal(in)
+	require.NoError(t, err)
+
+	var out cborDevice
+	require.NoError(t, CBOR.BindBody(body, &out))
+	assert.Equal(t, in, out)
+
+	// Fields are keyed by their cbor tag.
+	var keys map[string]any
+	require.NoError(t, cbor.Unmarshal(body, &keys))
+	assert.Contains(t, keys, "readings")
+	assert.Contains(t, keys, "location")
+}
+
+func TestCBORBindingBind(t *testing.T) {
+	body, err := cbor.Marshal(map[string]any{"id": "dev-2"})
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", MIMECBOR)
+	b := Default(http.MethodPost, MIMECBOR)
+	assert.Equal(t, "cbor", b.Name())
+
+	var out cborDevice
+	require.NoError(t, b.Bind(req, &out))
+	assert.Equal(t, "dev-2", out.ID)
+}
+
+func TestCBORBindingFail(t *testing.T) {
+	var out cborDevice
+	require.Error(t, CBOR.BindBody([]byte{0xff}, &out))
+
+	// Validation runs after decoding.
+	body, err := cbor.Marshal(map[string]any{"readings": []any{}})
+	require.NoError(t, err)
+	require.Error(t, CBOR.BindBody(body, &out))
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
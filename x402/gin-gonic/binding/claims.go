@@ -0,0 +1,100 @@
+/* claims.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+type claimsContextKey struct{}
+
+// ClaimsFromRequest returns the JWT claims of req, which must already have been
+// validated upstream. It defaults to the claims stored with ContextWithClaims and
+// can be replaced to read the claims from another source.
+var ClaimsFromRequest = func(req *http.Request) (map[string]any, bool) {
+	claims, ok := req.Context().Value(claimsContextKey{}).(map[string]any)
+	return claims, ok
+}
+
+// ContextWithClaims returns a copy of ctx carrying the validated JWT claims.
+func ContextWithClaims(ctx context.Context, claims map[string]any) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// errMissingClaims is returned when no claims are available for the request.
+var errMissingClaims = errors.New("no JWT claims found for request")
+
+type claimsBinding struct{}
+
+func (claimsBinding) Name() string {
+	return "claims"
+}
+
+func (claimsBinding) Bind(req *http.Request, obj any) error {
+	claims, ok := ClaimsFromRequest(req)
+	if !ok {
+		return errMissingClaims
+	}
+	if err := mapClaims(obj, claims); err != nil {
+		return err
+	}
+	return validate(obj)
+}
+
+// mapClaims maps claims onto obj through the `claim` tag. Claim values are
+// converted to their string form so the usual form setters apply.
+func mapClaims(ptr any, claims map[string]any) error {
+	values := make(map[string][]string, len(claims))
+	for name, claim := range claims {
+		vs, err := claimValues(claim)
+		if err != nil {
+			return err
+		}
+		values[name] = vs
+	}
+	return mappingByPtr(ptr, formSource(values), "claim")
+}
+
+func claimValues(claim any) ([]string, error) {
+	switch v := claim.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case json.Number:
+		return []string{v.String()}, nil
+	case []string:
+		return v, nil
+	case []any:
+		vs := make([]string, 0, len(v))
+		for _, elem := range v {
+			elemValues, err := claimValues(elem)
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, elemValues...)
+		}
+		return vs, nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(data)}, nil
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,116 @@
+/* csv.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// errCSVTarget is returned when a CSV body is bound to anything but a slice of structs.
+var errCSVTarget = errors.New("csv binding requires a pointer to a slice of structs")
+
+// CSVRowError is returned when a data row of a CSV body fails to bind or validate.
+type CSVRowError struct {
+	// Row is the 1-based number of the data row, the header row excluded.
+	Row int
+	Err error
+}
+
+// Error implements the error interface.
+func (e *CSVRowError) Error() string {
+	return fmt.Sprintf("csv row %d: %v", e.Row, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+type csvBinding struct{}
+
+func (csvBinding) Name() string {
+	return "csv"
+}
+
+func (csvBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
+	return decodeCSV(req.Body, obj)
+}
+
+func (csvBinding) BindBody(body []byte, obj any) error {
+	return decodeCSV(bytes.NewReader(body), obj)
+}
+
+// decodeCSV binds every data row of a CSV body with a header row into its own
+// element of the slice pointed to by obj. Columns are mapped to fields by their
+// `csv` tag, or their name, and each row is validated on its own.
+func decodeCSV(r io.Reader, obj any) error {
+	ptr := reflect.ValueOf(obj)
+	if ptr.Kind() != reflect.Pointer || ptr.Elem().Kind() != reflect.Slice {
+		return errCSVTarget
+	}
+	sliceType := ptr.Elem().Type()
+	elemType := sliceType.Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return errCSVTarget
+	}
+
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		ptr.Elem().Set(reflect.MakeSlice(sliceType, 0, 0))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rows := reflect.MakeSlice(sliceType, 0, 0)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return &CSVRowError{Row: row, Err: err}
+		}
+
+		form := make(map[string][]string, len(header))
+		for i, name := range header {
+			form[name] = []string{record[i]}
+		}
+		elem := reflect.New(elemType)
+		if err := mappingByPtr(elem.Interface(), formSource(form), "csv"); err != nil {
+			return &CSVRowError{Row: row, Err: err}
+		}
+		if err := validate(elem.Interface()); err != nil {
+			return &CSVRowError{Row: row, Err: err}
+		}
+
+		if sliceType.Elem().Kind() == reflect.Pointer {
+			rows = reflect.Append(rows, elem)
+		} else {
+			rows = reflect.Append(rows, elem.Elem())
+		}
+	}
+	ptr.Elem().Set(rows)
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
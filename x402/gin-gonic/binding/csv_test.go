@@ -0,0 +1,61 @@
+/* csv_test.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type csvItem struct {
+	Name string `csv:"name" binding:"required"`
+	Qty  int    `csv:"qty"`
+}
+
+func TestCSVBindingBindBody(t *testing.T) {
+	var items []csvItem
+	err := csvBinding{}.BindBody([]byte("name,qty\napple,2\npear,3\n"), &items)
+	require.NoError(t, err)
+	assert.Equal(t, []csvItem{{Name: "apple", Qty: 2}, {Name: "pear", Qty: 3}}, items)
+
+	var ptrs []*csvItem
+	err = csvBinding{}.BindBody([]byte("qty,name\n4,fig\n"), &ptrs)
+	require.NoError(t, err)
+	assert.Equal(t, []*csvItem{{Name: "fig", Qty: 4}}, ptrs)
+
+	assert.Equal(t, CSV, Default(http.MethodPost, MIMECSV))
+	assert.Equal(t, "csv", CSV.Name())
+}
+
+func TestCSVBindingErrors(t *testing.T) {
+	var items []csvItem
+	err := csvBinding{}.BindBody([]byte("name,qty\napple,2\npear,many\n"), &items)
+	var rowErr *CSVRowError
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 2, rowErr.Row)
+	assert.ErrorContains(t, err, "csv row 2: ")
+
+	err = csvBinding{}.BindBody([]byte("name,qty\napple,2\nkiwi\n"), &items)
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 2, rowErr.Row)
+
+	err = csvBinding{}.BindBody([]byte("name,qty\n,1\n"), &items)
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 1, rowErr.Row)
+	assert.ErrorContains(t, err, "required")
+
+	var item csvItem
+	require.ErrorIs(t, csvBinding{}.BindBody([]byte("name\na\n"), &item), errCSVTarget)
+	var names []string
+	require.ErrorIs(t, csvBinding{}.BindBody([]byte("name\na\n"), &names), errCSVTarget)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
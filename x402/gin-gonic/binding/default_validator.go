@@ -28,8 +28,48 @@ type defaultValidator struct {
 	validate *validator.Validate
 }
 
+// SliceValidationError holds the validation errors of a slice or array, the
+// error of each element at the element's index. Elements that passed are nil.
 type SliceValidationError []error
 
+// SliceElementError is the validation error of the element at Index.
+type SliceElementError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e SliceElementError) Error() string {
+	return "[" + strconv.Itoa(e.Index) + "]: " + e.Err.Error()
+}
+
+// Unwrap returns the validation error of the element.
+func (e SliceElementError) Unwrap() error {
+	return e.Err
+}
+
+// Elements returns the elements that failed validation with their index.
+func (err SliceValidationError) Elements() []SliceElementError {
+	var elems []SliceElementError
+	for i, e := range err {
+		if e != nil {
+			elems = append(elems, SliceElementError{Index: i, Err: e})
+		}
+	}
+	return elems
+}
+
+// Unwrap returns the non-nil element errors, so errors.As can reach them.
+func (err SliceValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(err))
+	for _, e := range err {
+		if e != nil {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
 // Error concatenates all error elements in SliceValidationError into a single string separated by \n.
 func (err SliceValidationError) Error() string {
 	if len(err) == 0 {
@@ -67,13 +107,16 @@ func (v *defaultValidator) ValidateStruct(obj any) error {
 		return v.validateStruct(obj)
 	case reflect.Slice, reflect.Array:
 		count := value.Len()
-		validateRet := make(SliceValidationError, 0)
+		var validateRet SliceValidationError
 		for i := range count {
 			if err := v.ValidateStruct(value.Index(i).Interface()); err != nil {
-				validateRet = append(validateRet, err)
+				if validateRet == nil {
+					validateRet = make(SliceValidationError, count)
+				}
+				validateRet[i] = err
 			}
 		}
-		if len(validateRet) == 0 {
+		if validateRet == nil {
 			return nil
 		}
 		return validateRet
@@ -85,7 +128,7 @@ func (v *defaultValidator) ValidateStruct(obj any) error {
 // validateStruct receives struct type
 func (v *defaultValidator) validateStruct(obj any) error {
 	v.lazyinit()
-	return v.validate.Struct(obj)
+	return requiredErrors(v.validate.Struct(obj))
 }
 
 // Engine returns the underlying validator engine which powers the default
@@ -101,6 +144,8 @@ func (v *defaultValidator) lazyinit() {
 	v.once.Do(func() {
 		v.validate = validator.New()
 		v.validate.SetTagName("binding")
+		_ = v.validate.RegisterValidation(requiredOneOfTag, validateRequiredOneOf, true)
+		_ = v.validate.RegisterValidation(afterTag, validateAfter, true)
 	})
 }
 
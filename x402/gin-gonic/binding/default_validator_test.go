@@ -16,7 +16,12 @@ package binding
 
 import (
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 )
 
 func TestSliceValidationError(t *testing.T) {
@@ -58,6 +63,35 @@ func TestSliceValidationError(t *testing.T) {
 	}
 }
 
+func TestSliceValidationErrorElements(t *testing.T) {
+	type item struct {
+		Name string `binding:"required"`
+		Qty  int    `binding:"gt=0"`
+	}
+	items := []item{{"a", 1}, {"", 1}, {"c", 1}, {"d", 0}}
+
+	err := (&defaultValidator{}).ValidateStruct(items)
+	var sliceErr SliceValidationError
+	if !errors.As(err, &sliceErr) {
+		t.Fatalf("expected SliceValidationError, got %T", err)
+	}
+
+	elems := sliceErr.Elements()
+	if len(elems) != 2 || elems[0].Index != 1 || elems[1].Index != 3 {
+		t.Fatalf("unexpected elements %v", elems)
+	}
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(elems[1], &fieldErrs) || fieldErrs[0].Field() != "Qty" {
+		t.Errorf("expected element 3 to fail on Qty, got %v", elems[1].Err)
+	}
+	if !errors.As(err, &fieldErrs) {
+		t.Errorf("expected errors.As to reach the element errors")
+	}
+	if got := err.Error(); !strings.HasPrefix(got, "[1]: ") || !strings.Contains(got, "\n[3]: ") {
+		t.Errorf("unexpected error message %q", got)
+	}
+}
+
 func TestDefaultValidator(t *testing.T) {
 	type exampleStruct struct {
 		A string `binding:"max=8"`
@@ -99,5 +133,97 @@ func TestDefaultValidator(t *testing.T) {
 	}
 }
 
+func TestDefaultValidatorRequiredOneOf(t *testing.T) {
+	type contact struct {
+		Email string  `form:"email" binding:"required_one_of=email phone"`
+		Phone *string `form:"phone"`
+	}
+	phone := "555-0100"
+
+	tests := []struct {
+		name    string
+		obj     any
+		wantErr bool
+	}{
+		{"none set", &contact{}, true},
+		{"email set", &contact{Email: "a@example.com"}, false},
+		{"phone set", &contact{Phone: &phone}, false},
+		{"both set", &contact{Email: "a@example.com", Phone: &phone}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&defaultValidator{}).ValidateStruct(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("defaultValidator.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+
+			fieldErrs, ok := err.(validator.ValidationErrors)
+			if !ok {
+				t.Fatalf("expected validator.ValidationErrors, got %T", err)
+			}
+			groupErr, ok := fieldErrs[0].(*RequiredGroupError)
+			if !ok {
+				t.Fatalf("expected RequiredGroupError, got %T", fieldErrs[0])
+			}
+			if strings.Join(groupErr.Group, ",") != "email,phone" {
+				t.Errorf("unexpected group %v", groupErr.Group)
+			}
+			if !strings.Contains(err.Error(), "at least one of [email phone] must be set") {
+				t.Errorf("unexpected error message %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestDefaultValidatorAfter(t *testing.T) {
+	type window struct {
+		Start time.Time  `form:"start" time_format:"2006-01-02"`
+		End   *time.Time `form:"end" time_format:"2006-01-02" binding:"after=Start"`
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{"valid range", "/?start=2024-01-01&end=2024-01-31", false},
+		{"inverted range", "/?start=2024-02-01&end=2024-01-31", true},
+		{"empty range", "/?start=2024-01-01&end=2024-01-01", true},
+		{"only start", "/?start=2024-01-01", false},
+		{"only end", "/?end=2024-01-31", false},
+		{"neither", "/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var obj window
+			err := Query.Bind(requestWithBody(http.MethodGet, tt.query, ""), &obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Query.Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+
+			fieldErrs, ok := err.(validator.ValidationErrors)
+			if !ok {
+				t.Fatalf("expected validator.ValidationErrors, got %T", err)
+			}
+			rangeErr, ok := fieldErrs[0].(*TimeRangeError)
+			if !ok {
+				t.Fatalf("expected TimeRangeError, got %T", fieldErrs[0])
+			}
+			if rangeErr.Field() != "End" || rangeErr.Param() != "Start" {
+				t.Errorf("unexpected error %+v", rangeErr)
+			}
+			if !strings.Contains(err.Error(), "Error:End must be after Start") {
+				t.Errorf("unexpected error message %q", err.Error())
+			}
+		})
+	}
+}
+
 
 /* ucm:n1che53569c8 */
\ No newline at end of file
@@ -32,6 +32,9 @@ func (formBinding) Name() string {
 }
 
 func (formBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	if err := req.ParseForm(); err != nil {
 		return err
 	}
@@ -49,6 +52,9 @@ func (formPostBinding) Name() string {
 }
 
 func (formPostBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	if err := req.ParseForm(); err != nil {
 		return err
 	}
@@ -63,6 +69,9 @@ func (formMultipartBinding) Name() string {
 }
 
 func (formMultipartBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	if err := req.ParseMultipartForm(defaultMemory); err != nil {
 		return err
 	}
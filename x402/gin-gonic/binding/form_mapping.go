@@ -25,12 +25,29 @@ var (
 	errUnknownType = errors.New("unknown type")
 
 	// ErrConvertMapStringSlice can not convert to map[string][]string
+	//
+	// Deprecated: maps of any value type are bound now, converting each value;
+	// a failed conversion returns the error of that value instead.
 	ErrConvertMapStringSlice = errors.New("can not convert to map slices of strings")
 
 	// ErrConvertToMapString can not convert to map[string]string
+	//
+	// Deprecated: maps of any value type are bound now, converting each value;
+	// a failed conversion returns the error of that value instead.
 	ErrConvertToMapString = errors.New("can not convert to map of strings")
 )
 
+// TimeNow returns the current time used to resolve relative time expressions
+// bound with `time_format:"relative"`. It can be replaced to use a fixed clock.
+var TimeNow = time.Now
+
+// FormTagPrecedence lists the struct tags naming a field for form and query
+// binding, the first present one wins. Add "json" to bind structs that only
+// carry json tags:
+//
+//	binding.FormTagPrecedence = []string{"form", "json"}
+var FormTagPrecedence = []string{"form"}
+
 func mapURI(ptr any, m map[string][]string) error {
 	return mapFormByTag(ptr, m, "uri")
 }
@@ -84,7 +101,7 @@ func mappingByPtr(ptr any, setter setter, tag string) error {
 }
 
 func mapping(value reflect.Value, field reflect.StructField, setter setter, tag string) (bool, error) {
-	if field.Tag.Get(tag) == "-" { // just ignoring this field
+	if fieldTag(field, tag) == "-" { // just ignoring this field
 		return false, nil
 	}
 
@@ -141,15 +158,19 @@ type setOptions struct {
 // id: n1ch-0las-4e4
 	isDefaultExists bool
 	defaultValue    string
+	// defaultIfAbsent keeps a present but empty value instead of using the default
+	defaultIfAbsent bool
 	// parser specifies what interface to use for reading the request & default values (e.g. `encoding.TextUnmarshaler`)
 	parser string
+	// tag is the struct tag used to map the fields of nested slice elements
+	tag string
 }
 
 func tryToSetValue(value reflect.Value, field reflect.StructField, setter setter, tag string) (bool, error) {
 	var tagValue string
-	var setOpt setOptions
+	setOpt := setOptions{tag: tag}
 
-	tagValue = field.Tag.Get(tag)
+	tagValue = fieldTag(field, tag)
 	tagValue, opts := head(tagValue, ",")
 
 	if tagValue == "" { // default value is FieldName
@@ -165,23 +186,53 @@ func tryToSetValue(value reflect.Value, field reflect.StructField, setter setter
 
 		if k, v := head(opt, "="); k == "default" {
 			setOpt.isDefaultExists = true
-			setOpt.defaultValue = v
-
-			// convert semicolon-separated default values to csv-separated values for processing in setByForm
-			if field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array {
-				cfTag := field.Tag.Get("collection_format")
-				if cfTag == "" || cfTag == "multi" || cfTag == "csv" {
-					setOpt.defaultValue = strings.ReplaceAll(v, ";", ",")
-				}
-			}
+			setOpt.defaultValue = defaultValue(field, v)
 		} else if k, v = head(opt, "="); k == "parser" {
 			setOpt.parser = v
 		}
 	}
 
+	// `default:"..."` applies only when the key is absent, an empty value stays empty
+	if v, ok := field.Tag.Lookup("default"); ok && !setOpt.isDefaultExists {
+		setOpt.isDefaultExists = true
+		setOpt.defaultIfAbsent = true
+		setOpt.defaultValue = defaultValue(field, v)
+	}
+
+	// `json:"inline"` marks a field whose form value is a JSON document
+	if setOpt.parser == "" && field.Tag.Get("json") == "inline" {
+		setOpt.parser = "json"
+	}
+
 	return setter.TrySet(value, field, tagValue, setOpt)
 }
 
+// fieldTag returns the tag of field, looking up the "form" tag through
+// FormTagPrecedence.
+func fieldTag(field reflect.StructField, tag string) string {
+	if tag != "form" {
+		return field.Tag.Get(tag)
+	}
+	for _, key := range FormTagPrecedence {
+		if v, ok := field.Tag.Lookup(key); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// defaultValue converts semicolon-separated default values of collection fields
+// to csv-separated values for processing in setByForm.
+func defaultValue(field reflect.StructField, v string) string {
+	if kind := derefType(field.Type).Kind(); kind == reflect.Slice || kind == reflect.Array {
+		cfTag := field.Tag.Get("collection_format")
+		if cfTag == "" || cfTag == "multi" || cfTag == "csv" {
+			return strings.ReplaceAll(v, ";", ",")
+		}
+	}
+	return v
+}
+
 // BindUnmarshaler is the interface used to wrap the UnmarshalParam method.
 type BindUnmarshaler interface {
 	// UnmarshalParam decodes and assigns a value from a form or query param.
@@ -209,11 +260,32 @@ func trySetUsingParser(val string, value reflect.Value, parser string) (isSet bo
 			return false, nil
 		}
 		return true, v.UnmarshalText([]byte(val))
+	case "json":
+		return true, json.API.Unmarshal(bytesconv.StringToBytes(val), value.Addr().Interface())
 	}
 	return false, nil
 }
 
+// splitDelimited splits each of vs on sep, dropping empty segments so that
+// `1||2` and a trailing `1|2|` both bind two values.
+func splitDelimited(vs []string, sep string) []string {
+	newVs := make([]string, 0, len(vs))
+	for _, v := range vs {
+		for _, segment := range strings.Split(v, sep) {
+			if segment != "" {
+				newVs = append(newVs, segment)
+			}
+		}
+	}
+	return newVs
+}
+
 func trySplit(vs []string, field reflect.StructField) (newVs []string, err error) {
+	// `delimiter:"|"` splits every value, repeated keys are still collected
+	if sep := field.Tag.Get("delimiter"); sep != "" {
+		return splitDelimited(vs, sep), nil
+	}
+
 	cfTag := field.Tag.Get("collection_format")
 	if cfTag == "" || cfTag == "multi" {
 		return vs, nil
@@ -247,6 +319,26 @@ func trySplit(vs []string, field reflect.StructField) (newVs []string, err error
 
 func setByForm(value reflect.Value, field reflect.StructField, form map[string][]string, tagValue string, opt setOptions) (isSet bool, err error) {
 	vs, ok := form[tagValue]
+	if !ok && value.Kind() == reflect.Map && value.Type().Key().Kind() == reflect.String {
+		if isSet, err = setFormMapField(value, field, form, tagValue, opt); isSet || err != nil {
+			return isSet, err
+		}
+	}
+	if !ok && value.Kind() == reflect.Slice && isStructType(value.Type().Elem()) {
+		if isSet, err = setFormSliceField(value, form, tagValue, opt); isSet || err != nil {
+			return isSet, err
+		}
+	}
+	if !ok && value.Kind() == reflect.Slice && !isStructType(value.Type().Elem()) {
+		if isSet, err = setFormIndexedSliceField(value, field, form, tagValue, opt); isSet || err != nil {
+			return isSet, err
+		}
+	}
+	if !ok && isStructType(value.Type()) {
+		if isSet, err = setFormStructField(value, form, tagValue, opt); isSet || err != nil {
+			return isSet, err
+		}
+	}
 	if !ok && !opt.isDefaultExists {
 		return false, nil
 	}
@@ -303,13 +395,14 @@ func setByForm(value reflect.Value, field reflect.StructField, form map[string][
 		}
 
 		if len(vs) != value.Len() {
-			return false, fmt.Errorf("%q is not valid value for %s", vs, value.Type().String())
+			return false, fmt.Errorf("%q is not valid value for %s: expected %d values, got %d",
+				vs, value.Type().String(), value.Len(), len(vs))
 		}
 
 		return true, setArray(vs, value, field, opt)
 	default:
 		var val string
-		if !ok || len(vs) == 0 || (len(vs) > 0 && vs[0] == "") {
+		if !ok || len(vs) == 0 || (len(vs) > 0 && vs[0] == "" && !opt.defaultIfAbsent) {
 			val = opt.defaultValue
 		} else if len(vs) > 0 {
 			val = vs[0]
@@ -363,6 +456,9 @@ func setWithProperType(val string, value reflect.Value, field reflect.StructFiel
 	case reflect.Uint64:
 		return setUintField(val, 64, value)
 	case reflect.Bool:
+		if ok, err := trySetBoolLiteral(val, value, field); ok {
+			return err
+		}
 		return setBoolField(val, value)
 	case reflect.Float32:
 		return setFloatField(val, 32, value)
@@ -425,6 +521,34 @@ func setBoolField(val string, field reflect.Value) error {
 	return err
 }
 
+// trySetBoolLiteral sets a bool using the comma-separated literals of the
+// `bool_true` and `bool_false` tags, e.g. `bool_true:"Y,enabled" bool_false:"N,disabled"`.
+// It returns false when the field declares no custom literals.
+func trySetBoolLiteral(val string, value reflect.Value, field reflect.StructField) (isSet bool, err error) {
+	trueTag, falseTag := field.Tag.Get("bool_true"), field.Tag.Get("bool_false")
+	if trueTag == "" && falseTag == "" {
+		return false, nil
+	}
+	if val == "" {
+		value.SetBool(false)
+		return true, nil
+	}
+
+	for literal := range strings.SplitSeq(trueTag, ",") {
+		if literal != "" && strings.EqualFold(val, strings.TrimSpace(literal)) {
+			value.SetBool(true)
+			return true, nil
+		}
+	}
+	for literal := range strings.SplitSeq(falseTag, ",") {
+		if literal != "" && strings.EqualFold(val, strings.TrimSpace(literal)) {
+			value.SetBool(false)
+			return true, nil
+		}
+	}
+	return true, fmt.Errorf("%q is not a valid boolean for %s (true: %q, false: %q)", val, field.Name, trueTag, falseTag)
+}
+
 func setFloatField(val string, bitSize int, field reflect.Value) error {
 	if val == "" {
 		val = "0.0"
@@ -468,6 +592,17 @@ func setTimeField(val string, structField reflect.StructField, value reflect.Val
 
 		value.Set(reflect.ValueOf(t))
 		return nil
+	case "relative":
+		t, ok, err := parseRelativeTime(val, TimeNow())
+		if err != nil {
+			return err
+		}
+		if ok {
+			value.Set(reflect.ValueOf(t))
+			return nil
+		}
+		// Not a relative expression, fall back to an absolute time
+		timeFormat = time.RFC3339
 	}
 
 	l := time.Local
@@ -492,8 +627,34 @@ func setTimeField(val string, structField reflect.StructField, value reflect.Val
 	return nil
 }
 
+// parseRelativeTime resolves expressions like "now", "now+1h" and "-30m"
+// against now. ok is false when val is not a relative expression.
+func parseRelativeTime(val string, now time.Time) (t time.Time, ok bool, err error) {
+	expr, hasNow := strings.CutPrefix(strings.ToLower(strings.TrimSpace(val)), "now")
+	if hasNow && expr == "" {
+		return now, true, nil
+	}
+	if expr == "" || (expr[0] != '+' && expr[0] != '-') {
+		if hasNow {
+			return time.Time{}, false, fmt.Errorf("invalid relative time %q", val)
+		}
+		return time.Time{}, false, nil
+	}
+
+	d, err := time.ParseDuration(expr)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid relative time %q: %w", val, err)
+	}
+	return now.Add(d), true, nil
+}
+
+// setArray sets the elements of value from vals. Pointer elements are left nil
+// for empty values, e.g. ids=1&ids=&ids=3 binds []*int{1, nil, 3}.
 func setArray(vals []string, value reflect.Value, field reflect.StructField, opt setOptions) error {
 	for i, s := range vals {
+		if s == "" && value.Index(i).Kind() == reflect.Ptr {
+			continue
+		}
 		err := setWithProperType(s, value.Index(i), field, opt)
 		if err != nil {
 			return err
@@ -534,25 +695,240 @@ func setFormMap(ptr any, form map[string][]string) error {
 	el := reflect.TypeOf(ptr).Elem()
 
 	if el.Kind() == reflect.Slice {
-		ptrMap, ok := ptr.(map[string][]string)
-		if !ok {
-			return ErrConvertMapStringSlice
+		if ptrMap, ok := ptr.(map[string][]string); ok {
+			maps.Copy(ptrMap, form)
+			return nil
+		}
+	} else if ptrMap, ok := ptr.(map[string]string); ok {
+		for k, v := range form {
+			ptrMap[k] = v[len(v)-1] // pick last
 		}
-		maps.Copy(ptrMap, form)
-
 		return nil
 	}
 
-	ptrMap, ok := ptr.(map[string]string)
+	// other value types are converted per value
+	m := reflect.ValueOf(ptr)
+	for k, v := range form {
+		if err := setMapEntry(m, k, v, emptyField, setOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFormMapField binds `key[name]=value` form entries into the map field value.
+// Maps of structs bind `key[name][field]=value` entries into each element.
+func setFormMapField(value reflect.Value, field reflect.StructField, form map[string][]string, key string, opt setOptions) (isSet bool, err error) {
+	if isStructType(value.Type().Elem()) {
+		return setFormStructMapField(value, form, key, opt)
+	}
+	for k, vs := range form {
+		name, ok := strings.CutPrefix(k, key+"[")
+		if !ok || !strings.HasSuffix(name, "]") || len(vs) == 0 {
+			continue
+		}
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		if err := setMapEntry(value, strings.TrimSuffix(name, "]"), vs, field, opt); err != nil {
+			return false, fmt.Errorf("%s: %w", k, err)
+		}
+		isSet = true
+	}
+	return isSet, nil
+}
+
+// maxFormSliceIndex bounds the indices accepted by setFormSliceField so a
+// single `key[n][name]` entry cannot allocate an arbitrarily large slice.
+const maxFormSliceIndex = 1000
+
+// setFormSliceField binds `key[i][name]=value` form entries into the slice of
+// structs field value, growing it to the highest index. Elements without any
+// entry keep their zero value.
+func setFormSliceField(value reflect.Value, form map[string][]string, key string, opt setOptions) (isSet bool, err error) {
+	elems := make(map[int]map[string][]string)
+	maxIndex := -1
+	for k, vs := range form {
+		index, sub, ok := splitFormKey(k, key)
+		if !ok || sub == "" {
+			continue
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			continue
+		}
+		if i > maxFormSliceIndex {
+			return false, fmt.Errorf("%s: index exceeds %d", k, maxFormSliceIndex)
+		}
+		if elems[i] == nil {
+			elems[i] = make(map[string][]string)
+		}
+		elems[i][sub] = vs
+		maxIndex = max(maxIndex, i)
+	}
+	if maxIndex < 0 {
+		return false, nil
+	}
+
+	slice := reflect.MakeSlice(value.Type(), maxIndex+1, maxIndex+1)
+	for i, sub := range elems {
+		if _, err := mapping(slice.Index(i), emptyField, formSource(sub), opt.tag); err != nil {
+			return false, fmt.Errorf("%s[%d]: %w", key, i, err)
+		}
+	}
+	value.Set(slice)
+	return true, nil
+}
+
+// setFormIndexedSliceField binds `key[i]=value` form entries into the slice
+// field value at their explicit indices, growing it to the highest index, e.g.
+// a[2]=x&a[0]=y binds ["y", "", "x"]. Gaps keep their zero value.
+func setFormIndexedSliceField(value reflect.Value, field reflect.StructField, form map[string][]string, key string, opt setOptions) (isSet bool, err error) {
+	elems := make(map[int]string)
+	maxIndex := -1
+	for k, vs := range form {
+		index, sub, ok := splitFormKey(k, key)
+		if !ok || sub != "" || len(vs) == 0 {
+			continue
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			continue
+		}
+		if i > maxFormSliceIndex {
+			return false, fmt.Errorf("%s: index exceeds %d", k, maxFormSliceIndex)
+		}
+		elems[i] = vs[0]
+		maxIndex = max(maxIndex, i)
+	}
+	if maxIndex < 0 {
+		return false, nil
+	}
+
+	slice := reflect.MakeSlice(value.Type(), maxIndex+1, maxIndex+1)
+	for i, val := range elems {
+		if val == "" && slice.Index(i).Kind() == reflect.Pointer {
+			continue
+		}
+		if err := setWithProperType(val, slice.Index(i), field, opt); err != nil {
+			return false, fmt.Errorf("%s[%d]: %w", key, i, err)
+		}
+	}
+	value.Set(slice)
+	return true, nil
+}
+
+// setFormStructField binds `key[name]=value` form entries into the struct field
+// value, as if its fields were bound from a form of `name=value` entries.
+// Structs nest arbitrarily, e.g. `filter[age][gt]=30` sets Filter.Age.Gt.
+func setFormStructField(value reflect.Value, form map[string][]string, key string, opt setOptions) (isSet bool, err error) {
+	sub := make(map[string][]string)
+	for k, vs := range form {
+		if rest, ok := strings.CutPrefix(k, key+"["); ok {
+			if name, tail, ok := strings.Cut(rest, "]"); ok {
+				sub[name+tail] = vs
+			}
+		}
+	}
+	if len(sub) == 0 {
+		return false, nil
+	}
+	if _, err := mapping(value, emptyField, formSource(sub), opt.tag); err != nil {
+		return false, fmt.Errorf("%s: %w", key, err)
+	}
+	return true, nil
+}
+
+// setFormStructMapField binds `key[name][field]=value` form entries into the
+// map of structs value, one element per name.
+func setFormStructMapField(value reflect.Value, form map[string][]string, key string, opt setOptions) (isSet bool, err error) {
+	elems := make(map[string]map[string][]string)
+	for k, vs := range form {
+		name, sub, ok := splitFormKey(k, key)
+		if !ok || sub == "" {
+			continue
+		}
+		if elems[name] == nil {
+			elems[name] = make(map[string][]string)
+		}
+		elems[name][sub] = vs
+	}
+	if len(elems) == 0 {
+		return false, nil
+	}
+
+	if value.IsNil() {
+		value.Set(reflect.MakeMap(value.Type()))
+	}
+	for name, sub := range elems {
+		elem := reflect.New(value.Type().Elem()).Elem()
+		if _, err := mapping(elem, emptyField, formSource(sub), opt.tag); err != nil {
+			return false, fmt.Errorf("%s[%s]: %w", key, name, err)
+		}
+		value.SetMapIndex(reflect.ValueOf(name).Convert(value.Type().Key()), elem)
+	}
+	return true, nil
+}
+
+// splitFormKey splits a `key[name]...` form key into name and the nested key
+// that follows it: items[0][name] gives 0 and name, items[0][tags][a] gives 0
+// and tags[a], and items[0] gives 0 and an empty nested key.
+func splitFormKey(k, key string) (name, sub string, ok bool) {
+	rest, ok := strings.CutPrefix(k, key+"[")
 	if !ok {
-		return ErrConvertToMapString
+		return "", "", false
 	}
-	for k, v := range form {
-		ptrMap[k] = v[len(v)-1] // pick last
+	name, rest, ok = strings.Cut(rest, "]")
+	if !ok {
+		return "", "", false
+	}
+	if rest == "" {
+		return name, "", true
+	}
+	if !strings.HasPrefix(rest, "[") {
+		return "", "", false
 	}
+	field, tail, _ := strings.Cut(rest[1:], "]")
+	return name, field + tail, true
+}
 
-	return nil
+// derefType returns the type pointed to by t, following any number of pointers.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isStructType reports whether t is a struct, or a pointer to one, whose
+// fields are bound individually rather than parsed from a single value.
+func isStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeFor[time.Time]() {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	return !pt.Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) && !pt.Implements(reflect.TypeFor[BindUnmarshaler]())
 }
 
+// setMapEntry converts vs to the map's value type and stores it under key.
+// Slice values take every value, other types the last one.
+func setMapEntry(m reflect.Value, key string, vs []string, field reflect.StructField, opt setOptions) error {
+	elem := reflect.New(m.Type().Elem()).Elem()
+	if elem.Kind() == reflect.Slice {
+		if err := setSlice(vs, elem, field, opt); err != nil {
+			return err
+		}
+	} else {
+		val := vs[len(vs)-1]
+		if err := setWithProperType(val, elem, field, opt); err != nil {
+			return fmt.Errorf("%q is not a valid %s: %w", val, elem.Type(), err)
+		}
+	}
+	m.SetMapIndex(reflect.ValueOf(key).Convert(m.Type().Key()), elem)
+	return nil
+}
 
 /* universal-crypto-mcp © @nichxbt */
\ No newline at end of file
@@ -258,6 +258,54 @@ func TestMappingTimeUnixNano(t *testing.T) {
 	}
 }
 
+func TestMappingTimeRelative(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	TimeNow = func() time.Time { return now }
+	defer func() { TimeNow = time.Now }()
+
+	var s struct {
+		Start time.Time `form:"start" time_format:"relative"`
+		End   time.Time `form:"end" time_format:"relative"`
+		At    time.Time `form:"at" time_format:"relative" time_utc:"1"`
+	}
+	err := mapForm(&s, map[string][]string{
+		"start": {"-30m"},
+		"end":   {"now+1h"},
+		"at":    {"2024-06-01T10:00:00Z"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-30*time.Minute), s.Start)
+	assert.Equal(t, now.Add(time.Hour), s.End)
+	assert.Equal(t, time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), s.At)
+
+	err = mapForm(&s, map[string][]string{"start": {"now"}})
+	require.NoError(t, err)
+	assert.Equal(t, now, s.Start)
+
+	err = mapForm(&s, map[string][]string{"start": {"now+soon"}})
+	require.Error(t, err)
+}
+
+func TestMappingBoolLiterals(t *testing.T) {
+	var s struct {
+		Active  bool `form:"active" bool_true:"Y,enabled" bool_false:"N,disabled"`
+		Feature bool `form:"feature" bool_true:"Y,enabled" bool_false:"N,disabled"`
+		Plain   bool `form:"plain"`
+	}
+
+	err := mapForm(&s, map[string][]string{"active": {"Y"}, "feature": {"disabled"}, "plain": {"true"}})
+	require.NoError(t, err)
+	assert.True(t, s.Active)
+	assert.False(t, s.Feature)
+	assert.True(t, s.Plain)
+
+	err = mapForm(&s, map[string][]string{"active": {"maybe"}})
+	require.EqualError(t, err, `"maybe" is not a valid boolean for Active (true: "Y,enabled", false: "N,disabled")`)
+
+	err = mapForm(&s, map[string][]string{"active": {"true"}})
+	require.Error(t, err)
+}
+
 func TestMappingTimeDuration(t *testing.T) {
 	type needFixDurationEmpty struct {
 		Duration time.Duration `form:"duration"`
@@ -308,6 +356,51 @@ func TestMappingSlice(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestMappingPtrToSlice(t *testing.T) {
+	var s struct {
+		Names   *[]string `form:"names"`
+		Default *[]string `form:"default,default=a;b"`
+		CSV     *[]int    `form:"csv" collection_format:"csv"`
+	}
+
+	// absent
+	err := mappingByPtr(&s, formSource{}, "form")
+	require.NoError(t, err)
+	assert.Nil(t, s.Names)
+	assert.Nil(t, s.CSV)
+	require.NotNil(t, s.Default)
+	assert.Equal(t, []string{"a", "b"}, *s.Default)
+
+	// present
+	err = mappingByPtr(&s, formSource{"names": {"x", "y"}, "csv": {"1,2"}}, "form")
+	require.NoError(t, err)
+	require.NotNil(t, s.Names)
+	assert.Equal(t, []string{"x", "y"}, *s.Names)
+	require.NotNil(t, s.CSV)
+	assert.Equal(t, []int{1, 2}, *s.CSV)
+}
+
+func TestMappingSliceOfPtr(t *testing.T) {
+	var s struct {
+		IDs []*int `form:"ids"`
+	}
+
+	err := mappingByPtr(&s, formSource{}, "form")
+	require.NoError(t, err)
+	assert.Nil(t, s.IDs)
+
+	// empty values leave their element nil
+	err = mappingByPtr(&s, formSource{"ids": {"1", "", "3"}}, "form")
+	require.NoError(t, err)
+	require.Len(t, s.IDs, 3)
+	assert.Equal(t, 1, *s.IDs[0])
+	assert.Nil(t, s.IDs[1])
+	assert.Equal(t, 3, *s.IDs[2])
+
+	err = mappingByPtr(&s, formSource{"ids": {"1", "two"}}, "form")
+	require.Error(t, err)
+}
+
 func TestMappingArray(t *testing.T) {
 	var s struct {
 		Array [2]int `form:"array,default=9"`
@@ -437,6 +530,31 @@ func TestMappingMultipleDefaultWithCollectionFormat(t *testing.T) {
 	assert.Equal(t, [2]string{"1", "2"}, s.ArrayStringPipes)
 }
 
+func TestMappingInlineJSON(t *testing.T) {
+	type item struct {
+		A int    `json:"a"`
+		B string `json:"b"`
+	}
+	var s struct {
+		Payload item   `form:"payload" json:"inline"`
+		Items   []item `form:"items,parser=json"`
+		Name    string `form:"name"`
+	}
+
+	err := mappingByPtr(&s, formSource{
+		"payload": {`{"a":1,"b":"x"}`},
+		"items":   {`[{"a":2},{"a":3}]`},
+		"name":    {"legacy"},
+	}, "form")
+	require.NoError(t, err)
+	assert.Equal(t, item{A: 1, B: "x"}, s.Payload)
+	assert.Equal(t, []item{{A: 2}, {A: 3}}, s.Items)
+	assert.Equal(t, "legacy", s.Name)
+
+	err = mappingByPtr(&s, formSource{"payload": {`{"a":`}}, "form")
+	require.Error(t, err)
+}
+
 func TestMappingStructField(t *testing.T) {
 	var s struct {
 		J struct {
@@ -19,6 +19,8 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin/codec/json"
 )
@@ -35,6 +37,33 @@ var EnableDecoderUseNumber = false
 // keys which do not match any non-ignored, exported fields in the destination.
 var EnableDecoderDisallowUnknownFields = false
 
+// ErrUnknownField is matched by errors.Is for every UnknownFieldError.
+var ErrUnknownField = errors.New("unknown field")
+
+// UnknownFieldError is returned when EnableDecoderDisallowUnknownFields is set
+// and the body has a key that matches no field of the destination.
+type UnknownFieldError struct {
+	// Field is the offending key.
+	Field string
+
+	err error
+}
+
+// Error implements the error interface.
+func (e *UnknownFieldError) Error() string {
+	return "json: unknown field " + strconv.Quote(e.Field)
+}
+
+// Is reports whether target is ErrUnknownField.
+func (e *UnknownFieldError) Is(target error) bool {
+	return target == ErrUnknownField
+}
+
+// Unwrap returns the decoder error.
+func (e *UnknownFieldError) Unwrap() error {
+	return e.err
+}
+
 type jsonBinding struct{}
 
 func (jsonBinding) Name() string {
@@ -45,6 +74,9 @@ func (jsonBinding) Bind(req *http.Request, obj any) error {
 	if req == nil || req.Body == nil {
 		return errors.New("invalid request")
 	}
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	return decodeJSON(req.Body, obj)
 }
 
@@ -62,10 +94,30 @@ func decodeJSON(r io.Reader, obj any) error {
 		decoder.DisallowUnknownFields()
 	}
 	if err := decoder.Decode(obj); err != nil {
+		if EnableDecoderDisallowUnknownFields {
+			return unknownFieldError(err)
+		}
 		return err
 	}
 	return validate(obj)
 }
 
+// unknownFieldError turns the unknown field error of the JSON decoder into an
+// UnknownFieldError. The decoders supported by codec/json report the field as
+// `unknown field "name"` or `unknown field: name,`.
+func unknownFieldError(err error) error {
+	_, field, ok := strings.Cut(err.Error(), "unknown field")
+	if !ok {
+		return err
+	}
+	field = strings.TrimLeft(field, ": ")
+	if quoted, ok := strings.CutPrefix(field, `"`); ok {
+		field, _, _ = strings.Cut(quoted, `"`)
+	} else {
+		field, _, _ = strings.Cut(field, ",")
+	}
+	return &UnknownFieldError{Field: field, err: err}
+}
+
 
 /* universal-crypto-mcp © n1ch0las */
\ No newline at end of file
@@ -0,0 +1,135 @@
+/* json_dotted.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// dottedJSONBinding binds JSON objects whose dotted keys are paths into nested
+// objects, e.g. {"db.host": "x"} binds like {"db": {"host": "x"}}. Dotted and
+// nested keys may be mixed as long as no path is set twice.
+type dottedJSONBinding struct{}
+
+func (dottedJSONBinding) Name() string {
+	return "json-dotted"
+}
+
+func (dottedJSONBinding) Bind(req *http.Request, obj any) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
+	return decodeDottedJSON(req.Body, obj)
+}
+
+func (dottedJSONBinding) BindBody(body []byte, obj any) error {
+	return decodeDottedJSON(bytes.NewReader(body), obj)
+}
+
+func decodeDottedJSON(r io.Reader, obj any) error {
+	decoder := json.API.NewDecoder(r)
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return err
+	}
+
+	expanded, err := expandDottedKeys(fields)
+	if err != nil {
+		return err
+	}
+	body, err := json.API.Marshal(expanded)
+	if err != nil {
+		return err
+	}
+	return decodeJSON(bytes.NewReader(body), obj)
+}
+
+// expandDottedKeys returns the objects of v, at any depth, with their dotted
+// keys expanded into nested objects.
+func expandDottedKeys(v any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, value := range v {
+			value, err := expandDottedKeys(value)
+			if err != nil {
+				return nil, err
+			}
+			if err := setDottedPath(expanded, key, value); err != nil {
+				return nil, err
+			}
+		}
+		return expanded, nil
+	case []any:
+		for i, elem := range v {
+			elem, err := expandDottedKeys(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = elem
+		}
+	}
+	return v, nil
+}
+
+// setDottedPath sets value at the dotted path key of obj, merging objects set
+// at the same path.
+func setDottedPath(obj map[string]any, key string, value any) error {
+	parts := strings.Split(key, ".")
+	for _, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid dotted key %q", key)
+		}
+	}
+
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := obj[part]
+		if !ok {
+			next = make(map[string]any)
+			obj[part] = next
+		}
+		nested, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key %q conflicts with another key", key)
+		}
+		obj = nested
+	}
+	return mergeDottedValue(obj, parts[len(parts)-1], key, value)
+}
+
+func mergeDottedValue(obj map[string]any, name, key string, value any) error {
+	existing, ok := obj[name]
+	if !ok {
+		obj[name] = value
+		return nil
+	}
+	existingObj, ok1 := existing.(map[string]any)
+	valueObj, ok2 := value.(map[string]any)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("key %q conflicts with another key", key)
+	}
+	for k, v := range valueObj {
+		if err := mergeDottedValue(existingObj, k, key+"."+k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,79 @@
+/* json_stream.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// errJSONStreamTarget is returned when a streamed JSON body is not an array.
+var errJSONStreamTarget = errors.New("json stream binding requires a JSON array body")
+
+// JSONElementError is returned when an element of a streamed JSON array is
+// malformed or rejected by the callback.
+type JSONElementError struct {
+	// Index is the 0-based index of the element in the array.
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *JSONElementError) Error() string {
+	return fmt.Sprintf("json element %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *JSONElementError) Unwrap() error {
+	return e.Err
+}
+
+// BindJSONStream reads the JSON array body of req one element at a time,
+// calling fn with each element in order, so only a single element is held
+// in memory. Each element is checked to be well-formed JSON before fn is
+// called; fn can bind and validate it with JSON.BindBody. Reading stops at
+// the first error, returned as a JSONElementError for element failures.
+func BindJSONStream(req *http.Request, fn func(elem json.RawMessage) error) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
+	return decodeJSONStream(req.Body, fn)
+}
+
+func decodeJSONStream(r io.Reader, fn func(elem json.RawMessage) error) error {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return errJSONStreamTarget
+	}
+
+	for i := 0; decoder.More(); i++ {
+		var elem json.RawMessage
+		if err := decoder.Decode(&elem); err != nil {
+			return &JSONElementError{Index: i, Err: err}
+		}
+		if err := fn(elem); err != nil {
+			return &JSONElementError{Index: i, Err: err}
+		}
+	}
+
+	// consume the closing bracket so a truncated body is reported
+	_, err = decoder.Token()
+	return err
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,95 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindJSONStream(t *testing.T) {
+	const count = 10000
+
+	// The body is produced lazily: everything past the first element is only
+	// written once the callback has seen it, which deadlocks a binding that
+	// reads the whole array up front.
+	pr, pw := io.Pipe()
+	first := make(chan struct{})
+	go func() {
+		fmt.Fprint(pw, `[{"id":0}`)
+		select {
+		case <-first:
+		case <-time.After(5 * time.Second):
+			pw.CloseWithError(errors.New("first element was not delivered before the body ended"))
+			return
+		}
+		for i := 1; i < count; i++ {
+			fmt.Fprintf(pw, `,{"id":%d}`, i)
+		}
+		fmt.Fprint(pw, `]`)
+		pw.Close()
+	}()
+
+	req := httptest.NewRequest(http.MethodPost, "/", pr)
+	next := 0
+	err := BindJSONStream(req, func(elem json.RawMessage) error {
+		var obj struct {
+			ID *int `json:"id" binding:"required"`
+		}
+		if err := JSON.BindBody(elem, &obj); err != nil {
+			return err
+		}
+		assert.Equal(t, next, *obj.ID)
+		if next == 0 {
+			close(first)
+		}
+		next++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, count, next)
+}
+
+func TestBindJSONStreamErrors(t *testing.T) {
+	noop := func(json.RawMessage) error { return nil }
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":1}`))
+	require.ErrorIs(t, BindJSONStream(req, noop), errJSONStreamTarget)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[1, 2, }`))
+	var elemErr *JSONElementError
+	require.ErrorAs(t, BindJSONStream(req, noop), &elemErr)
+	assert.Equal(t, 2, elemErr.Index)
+
+	errRejected := errors.New("rejected")
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[1, 2, 3]`))
+	err := BindJSONStream(req, func(elem json.RawMessage) error {
+		if string(elem) == "2" {
+			return errRejected
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errRejected)
+	require.ErrorAs(t, err, &elemErr)
+	assert.Equal(t, 1, elemErr.Index)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[1, 2`))
+	require.Error(t, BindJSONStream(req, noop))
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
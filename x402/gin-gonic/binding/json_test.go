@@ -15,8 +15,11 @@
 package binding
 
 import (
+	"errors"
 	"io"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 	"unsafe"
@@ -38,6 +41,32 @@ func TestJSONBindingBindBody(t *testing.T) {
 	assert.Equal(t, "FOO", s.Foo)
 }
 
+func TestJSONBindingUnknownFields(t *testing.T) {
+	type login struct {
+		User string `json:"user"`
+	}
+	body := []byte(`{"user": "gin", "pasword": "typo"}`)
+
+	var obj login
+	require.NoError(t, jsonBinding{}.BindBody(body, &obj))
+	assert.Equal(t, "gin", obj.User)
+
+	EnableDecoderDisallowUnknownFields = true
+	defer func() { EnableDecoderDisallowUnknownFields = false }()
+
+	err := jsonBinding{}.BindBody(body, &login{})
+	require.ErrorIs(t, err, ErrUnknownField)
+	var fieldErr *UnknownFieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "pasword", fieldErr.Field)
+	assert.Equal(t, `json: unknown field "pasword"`, err.Error())
+
+	require.NoError(t, jsonBinding{}.BindBody([]byte(`{"user": "gin"}`), &login{}))
+	require.NotErrorIs(t, jsonBinding{}.BindBody([]byte(`{"user": 1}`), &login{}), ErrUnknownField)
+
+	assert.Equal(t, "field", unknownFieldError(errors.New("found unknown field: field, error found in #10 byte")).(*UnknownFieldError).Field)
+}
+
 func TestJSONBindingBindBodyMap(t *testing.T) {
 	s := make(map[string]string)
 	err := jsonBinding{}.BindBody([]byte(`{"foo": "FOO","hello":"world"}`), &s)
@@ -47,6 +76,35 @@ func TestJSONBindingBindBodyMap(t *testing.T) {
 	assert.Equal(t, "world", s["hello"])
 }
 
+func TestDottedJSONBinding(t *testing.T) {
+	type config struct {
+		DB struct {
+			Host string `json:"host" binding:"required"`
+			Port int    `json:"port"`
+			Pool struct {
+				Size int `json:"size"`
+			} `json:"pool"`
+		} `json:"db"`
+		Name string `json:"name"`
+	}
+
+	var obj config
+	require.NoError(t, DottedJSON.BindBody([]byte(`{"db.host":"x","db.port":5432,"db":{"pool.size":4},"name":"app"}`), &obj))
+	assert.Equal(t, "json-dotted", DottedJSON.Name())
+	assert.Equal(t, "x", obj.DB.Host)
+	assert.Equal(t, 5432, obj.DB.Port)
+	assert.Equal(t, 4, obj.DB.Pool.Size)
+	assert.Equal(t, "app", obj.Name)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"db.port":5432}`))
+	require.Error(t, DottedJSON.Bind(req, &config{}), "db.host is required")
+
+	require.ErrorContains(t, DottedJSON.BindBody([]byte(`{"db":"x","db.host":"y"}`), &config{}), "conflicts")
+	require.ErrorContains(t, DottedJSON.BindBody([]byte(`{"db":{"host":"x"},"db.host":"y"}`), &config{}), "conflicts")
+	require.ErrorContains(t, DottedJSON.BindBody([]byte(`{"db..host":"x"}`), &config{}), "invalid dotted key")
+	require.Error(t, DottedJSON.BindBody([]byte(`[1]`), &config{}))
+}
+
 func TestCustomJsonCodec(t *testing.T) {
 	// Restore json encoding configuration after testing
 	oldMarshal := json.API
@@ -29,6 +29,9 @@ func (msgpackBinding) Name() string {
 }
 
 func (msgpackBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	return decodeMsgPack(req.Body, obj)
 }
 
@@ -18,6 +18,9 @@ func (plainBinding) Name() string {
 }
 
 func (plainBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	all, err := io.ReadAll(req.Body)
 	if err != nil {
 		return err
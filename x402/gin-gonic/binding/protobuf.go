@@ -22,6 +22,9 @@ func (protobufBinding) Name() string {
 }
 
 func (b protobufBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	buf, err := io.ReadAll(req.Body)
 	if err != nil {
 		return err
@@ -0,0 +1,140 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// requiredOneOfTag validates that at least one field of a space separated group
+// is set, e.g. `binding:"required_one_of=email phone"`. Group members are matched
+// by their form or json name, or case-insensitively by field name.
+const requiredOneOfTag = "required_one_of"
+
+// RequiredGroupError is the validator.FieldError reported in
+// validator.ValidationErrors when none of the fields of a required group is set.
+type RequiredGroupError struct {
+	validator.FieldError
+	// Group lists the fields of which at least one must be set.
+	Group []string
+}
+
+// Error implements the error interface.
+func (e *RequiredGroupError) Error() string {
+	return "Key: '" + e.Namespace() + "' Error:at least one of [" + strings.Join(e.Group, " ") + "] must be set"
+}
+
+// Unwrap returns the underlying validator.FieldError.
+func (e *RequiredGroupError) Unwrap() error {
+	return e.FieldError
+}
+
+func validateRequiredOneOf(fl validator.FieldLevel) bool {
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Pointer {
+		if parent.IsNil() {
+			return false
+		}
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return false
+	}
+
+	for _, name := range strings.Fields(fl.Param()) {
+		if field, ok := groupField(parent, name); ok && !field.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// groupField looks up the field of struct v named name by its form or json
+// tag, falling back to a case-insensitive match on the field name.
+func groupField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		for _, key := range []string{"form", "json"} {
+			if tagName, _, _ := strings.Cut(field.Tag.Get(key), ","); tagName == name {
+				return v.Field(i), true
+			}
+		}
+	}
+	for i := range t.NumField() {
+		if strings.EqualFold(t.Field(i).Name, name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// ConditionalRequiredError is the validator.FieldError reported in
+// validator.ValidationErrors when a field tagged required_with,
+// required_with_all, required_without or required_without_all is missing.
+type ConditionalRequiredError struct {
+	validator.FieldError
+	// Others lists the fields the requirement depends on.
+	Others []string
+}
+
+// Error implements the error interface.
+func (e *ConditionalRequiredError) Error() string {
+	others := strings.Join(e.Others, ", ")
+	var when string
+	switch e.Tag() {
+	case "required_with":
+		when = "when " + others + " is set"
+		if len(e.Others) > 1 {
+			when = "when any of " + others + " is set"
+		}
+	case "required_with_all":
+		when = "when " + others + " are all set"
+	case "required_without":
+		when = "when " + others + " is not set"
+		if len(e.Others) > 1 {
+			when = "when any of " + others + " is not set"
+		}
+	case "required_without_all":
+		when = "when none of " + others + " is set"
+	}
+	return "Key: '" + e.Namespace() + "' Error:" + e.Field() + " is required " + when
+}
+
+// Unwrap returns the underlying validator.FieldError.
+func (e *ConditionalRequiredError) Unwrap() error {
+	return e.FieldError
+}
+
+// requiredErrors wraps the required_one_of, conditional required and after
+// failures of err in RequiredGroupError, ConditionalRequiredError and
+// TimeRangeError for their messages. err stays a validator.ValidationErrors.
+func requiredErrors(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	for i, fe := range fieldErrs {
+		switch fe.Tag() {
+		case requiredOneOfTag:
+			fieldErrs[i] = &RequiredGroupError{FieldError: fe, Group: strings.Fields(fe.Param())}
+		case afterTag:
+			fieldErrs[i] = &TimeRangeError{FieldError: fe}
+		case "required_with", "required_with_all", "required_without", "required_without_all":
+			fieldErrs[i] = &ConditionalRequiredError{FieldError: fe, Others: strings.Fields(fe.Param())}
+		}
+	}
+	return err
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
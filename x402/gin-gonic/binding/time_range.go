@@ -0,0 +1,72 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// afterTag validates that a time.Time field is after another field of the
+// same struct, e.g. `binding:"after=Start"`. The check is skipped unless both
+// fields are set.
+const afterTag = "after"
+
+// TimeRangeError is the validator.FieldError reported in
+// validator.ValidationErrors when a field tagged after is not after the field
+// it is compared with, named by Param.
+type TimeRangeError struct {
+	validator.FieldError
+}
+
+// Error implements the error interface.
+func (e *TimeRangeError) Error() string {
+	return "Key: '" + e.Namespace() + "' Error:" + e.Field() + " must be after " + e.Param()
+}
+
+// Unwrap returns the underlying validator.FieldError.
+func (e *TimeRangeError) Unwrap() error {
+	return e.FieldError
+}
+
+func validateAfter(fl validator.FieldLevel) bool {
+	end, ok := timeValue(fl.Field())
+	if !ok {
+		return false
+	}
+	other, _, _, found := fl.GetStructFieldOK2()
+	if !found {
+		return false
+	}
+	start, ok := timeValue(other)
+	if !ok {
+		return false
+	}
+	if end.IsZero() || start.IsZero() {
+		return true
+	}
+	return end.After(start)
+}
+
+// timeValue returns the time.Time held by v, the zero time for a nil pointer.
+func timeValue(v reflect.Value) (time.Time, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return time.Time{}, true
+		}
+		v = v.Elem()
+	}
+	if v.Type() != reflect.TypeFor[time.Time]() {
+		return time.Time{}, false
+	}
+	return v.Interface().(time.Time), true
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -16,30 +16,51 @@ package binding
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/pelletier/go-toml/v2"
 )
 
-type tomlBinding struct{}
+// tomlBinding decodes TOML bodies. Keys without a matching struct field are
+// ignored unless strict is set, see TOMLStrict.
+type tomlBinding struct {
+	strict bool
+}
 
 func (tomlBinding) Name() string {
 	return "toml"
 }
 
-func (tomlBinding) Bind(req *http.Request, obj any) error {
-	return decodeToml(req.Body, obj)
+func (b tomlBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
+	return decodeToml(req.Body, obj, b.strict)
 }
 
-func (tomlBinding) BindBody(body []byte, obj any) error {
-	return decodeToml(bytes.NewReader(body), obj)
+func (b tomlBinding) BindBody(body []byte, obj any) error {
+	return decodeToml(bytes.NewReader(body), obj, b.strict)
 }
 
 // [nich] implementation
-func decodeToml(r io.Reader, obj any) error {
+func decodeToml(r io.Reader, obj any, strict bool) error {
 	decoder := toml.NewDecoder(r)
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
 	if err := decoder.Decode(obj); err != nil {
+		var missing *toml.StrictMissingError
+		if errors.As(err, &missing) {
+			keys := make([]string, len(missing.Errors))
+			for i, e := range missing.Errors {
+				keys[i] = strings.Join(e.Key(), ".")
+			}
+			return fmt.Errorf("%w: %s", err, strings.Join(keys, ", "))
+		}
 		return err
 	}
 	return validate(obj)
@@ -15,8 +15,12 @@
 package binding
 
 import (
+	"bytes"
+	"net/http"
 	"testing"
 
+	"github.com/pelletier/go-toml/v2"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,5 +36,29 @@ func TestTOMLBindingBindBody(t *testing.T) {
 	assert.Equal(t, "FOO", s.Foo)
 }
 
+func TestTOMLStrictBindingUnknownFields(t *testing.T) {
+	type config struct {
+		Foo string `toml:"foo"`
+	}
+	body := []byte("foo = \"FOO\"\n\n[extra]\nbar = 1\n")
+
+	var lenient config
+	require.NoError(t, TOML.BindBody(body, &lenient))
+	assert.Equal(t, "FOO", lenient.Foo)
+
+	var strict config
+	err := TOMLStrict.BindBody(body, &strict)
+	require.Error(t, err)
+	var missing *toml.StrictMissingError
+	require.ErrorAs(t, err, &missing)
+	assert.Contains(t, err.Error(), "extra")
+
+	req, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	require.Error(t, TOMLStrict.Bind(req, &strict))
+
+	require.NoError(t, TOMLStrict.BindBody([]byte(`foo = "FOO"`), &strict))
+	assert.Equal(t, "FOO", strict.Foo)
+}
+
 
 /* EOF - @nichxbt | 6e696368-786274-4d43-5000-000000000000 */
\ No newline at end of file
@@ -0,0 +1,151 @@
+// ucm:0x6E696368:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// TransformFunc transforms the value of a string field during binding.
+type TransformFunc func(string) string
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = map[string]TransformFunc{
+		"lowercase":         strings.ToLower,
+		"uppercase":         strings.ToUpper,
+		"trim":              strings.TrimSpace,
+		"normalize-unicode": norm.NFC.String,
+	}
+)
+
+// RegisterTransform registers a transform usable in `transform` tags,
+// replacing any transform already registered under name.
+func RegisterTransform(name string, fn TransformFunc) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	transforms[name] = fn
+}
+
+func lookupTransform(name string) (TransformFunc, bool) {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	fn, ok := transforms[name]
+	return fn, ok
+}
+
+// Normalizer is implemented by binding targets that canonicalize their own
+// values, e.g. lowercasing an email or computing derived fields. Normalize
+// runs after binding and `transform` tags, and before validation; its error
+// is returned by the binding as is.
+type Normalizer interface {
+	Normalize() error
+}
+
+// normalize calls Normalize on obj if it is a Normalizer.
+func normalize(obj any) error {
+	if n, ok := obj.(Normalizer); ok {
+		return n.Normalize()
+	}
+	return nil
+}
+
+// applyTransforms applies the comma separated transforms of `transform` tags,
+// in order, to the string fields of obj, e.g. `transform:"trim,lowercase"`.
+// Nested structs, pointers, slices and arrays are walked as well.
+func applyTransforms(obj any) error {
+	return transformValue(reflect.ValueOf(obj))
+}
+
+func transformValue(v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fv := v.Field(i)
+			if tag := field.Tag.Get("transform"); tag != "" && tag != "-" {
+				if err := transformField(fv, field, tag); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := transformValue(fv); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.String {
+			return nil
+		}
+		for i := range v.Len() {
+			if err := transformValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// transformField applies tag to a string, *string, or string slice field.
+func transformField(v reflect.Value, field reflect.StructField, tag string) error {
+	var fns []TransformFunc
+	for name := range strings.SplitSeq(tag, ",") {
+		name = strings.TrimSpace(name)
+		fn, ok := lookupTransform(name)
+		if !ok {
+			return fmt.Errorf("unknown transform %q for field %s", name, field.Name)
+		}
+		fns = append(fns, fn)
+	}
+	apply := func(s reflect.Value) {
+		if !s.CanSet() {
+			return
+		}
+		str := s.String()
+		for _, fn := range fns {
+			str = fn(str)
+		}
+		s.SetString(str)
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		apply(v)
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.String:
+		for i := range v.Len() {
+			apply(v.Index(i))
+		}
+	default:
+		return fmt.Errorf("transform on non-string field %s", field.Name)
+	}
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,80 @@
+/* warnings.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Warnings captures the non-fatal validations of a binding target. Embed it
+// in the target and declare warning level constraints in `warn` tags, which
+// take the same validations as `binding` tags:
+//
+//	type Signup struct {
+//		binding.Warnings
+//		Age int `form:"age" binding:"required" warn:"max=120"`
+//	}
+//
+// A failed `warn` validation does not fail the binding; it is reported by
+// Err once binding succeeded.
+//
+// `warn` tags are checked by their own validator, which only knows the
+// built-in validations plus required_one_of and after. Validations
+// registered on the binding Validator's Engine are not available there, a
+// `warn` tag using one fails the binding with an error.
+type Warnings struct {
+	err error
+}
+
+// Err returns the warning level validation errors of the last binding, nil
+// when there are none.
+func (w *Warnings) Err() error {
+	return w.err
+}
+
+func (w *Warnings) setBindingWarnings(err error) {
+	w.err = err
+}
+
+// warningsSetter is implemented by binding targets embedding Warnings.
+type warningsSetter interface {
+	setBindingWarnings(err error)
+}
+
+var (
+	warnValidatorOnce sync.Once
+	warnValidator     *validator.Validate
+)
+
+// collectWarnings validates the `warn` tags of obj if it embeds Warnings,
+// recording the failures there. It returns an error when the `warn` tags
+// cannot be validated, e.g. because they use an unknown validation.
+func collectWarnings(obj any) (err error) {
+	w, ok := obj.(warningsSetter)
+	if !ok {
+		return nil
+	}
+	warnValidatorOnce.Do(func() {
+		warnValidator = validator.New()
+		warnValidator.SetTagName("warn")
+		_ = warnValidator.RegisterValidation(requiredOneOfTag, validateRequiredOneOf, true)
+		_ = warnValidator.RegisterValidation(afterTag, validateAfter, true)
+	})
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid warn tag: %v", r)
+		}
+	}()
+	w.setBindingWarnings(requiredErrors(warnValidator.Struct(obj)))
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
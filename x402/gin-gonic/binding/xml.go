@@ -20,6 +20,9 @@ func (xmlBinding) Name() string {
 }
 
 func (xmlBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	return decodeXML(req.Body, obj)
 }
 
@@ -8,8 +8,10 @@ package binding
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net/http"
+	"reflect"
 
 	"github.com/goccy/go-yaml"
 )
@@ -21,6 +23,9 @@ func (yamlBinding) Name() string {
 }
 
 func (yamlBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
 	return decodeYAML(req.Body, obj)
 }
 
@@ -37,5 +42,50 @@ func decodeYAML(r io.Reader, obj any) error {
 	return validate(obj)
 }
 
+// errYAMLStreamTarget is returned when a YAML stream is bound to anything but a slice.
+var errYAMLStreamTarget = errors.New("yaml stream binding requires a pointer to a slice")
+
+type yamlStreamBinding struct{}
+
+func (yamlStreamBinding) Name() string {
+	return "yaml-stream"
+}
+
+func (yamlStreamBinding) Bind(req *http.Request, obj any) error {
+	if err := checkContentLength(req); err != nil {
+		return err
+	}
+	return decodeYAMLStream(req.Body, obj)
+}
+
+func (yamlStreamBinding) BindBody(body []byte, obj any) error {
+	return decodeYAMLStream(bytes.NewReader(body), obj)
+}
+
+// decodeYAMLStream decodes every document of a `---` separated stream
+// into its own element of the slice pointed to by obj.
+func decodeYAMLStream(r io.Reader, obj any) error {
+	ptr := reflect.ValueOf(obj)
+	if ptr.Kind() != reflect.Pointer || ptr.Elem().Kind() != reflect.Slice {
+		return errYAMLStreamTarget
+	}
+
+	sliceType := ptr.Elem().Type()
+	docs := reflect.MakeSlice(sliceType, 0, 0)
+	decoder := yaml.NewDecoder(r)
+	for {
+		doc := reflect.New(sliceType.Elem())
+		if err := decoder.Decode(doc.Interface()); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		docs = reflect.Append(docs, doc.Elem())
+	}
+	ptr.Elem().Set(docs)
+	return validate(obj)
+}
+
 
 /* universal-crypto-mcp © nichxbt */
\ No newline at end of file
@@ -23,5 +23,26 @@ func TestYAMLBindingBindBody(t *testing.T) {
 	assert.Equal(t, "FOO", s.Foo)
 }
 
+func TestYAMLStreamBindingBindBody(t *testing.T) {
+	type Config struct {
+		Name    string `yaml:"name"`
+		Replica int    `yaml:"replica"`
+	}
+	body := "name: api\nreplica: 2\n---\nname: worker\nreplica: 4\n---\nname: cron\nreplica: 1\n"
+
+	var configs []Config
+	err := YAMLStream.BindBody([]byte(body), &configs)
+	require.NoError(t, err)
+	assert.Equal(t, []Config{
+		{Name: "api", Replica: 2},
+		{Name: "worker", Replica: 4},
+		{Name: "cron", Replica: 1},
+	}, configs)
+
+	var single Config
+	err = YAMLStream.BindBody([]byte(body), &single)
+	require.Error(t, err)
+}
+
 
 /* ucm:n1ch7e230225 */
\ No newline at end of file
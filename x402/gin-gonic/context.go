@@ -12,6 +12,7 @@
 package gin
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -32,6 +33,7 @@ import (
 	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/gin-gonic/gin/render"
+	"google.golang.org/protobuf/proto"
 )
 
 // Content-Type MIME of the most common data formats.
@@ -46,6 +48,7 @@ const (
 	MIMEYAML              = binding.MIMEYAML
 	MIMEYAML2             = binding.MIMEYAML2
 	MIMETOML              = binding.MIMETOML
+	MIMECBOR              = binding.MIMECBOR
 	MIMEPROTOBUF          = binding.MIMEPROTOBUF
 )
 
@@ -55,6 +58,9 @@ const BodyBytesKey = "_gin-gonic/gin/bodybyteskey"
 // ContextKey is the key that a Context returns itself for.
 const ContextKey = "_gin-gonic/gin/contextkey"
 
+// TraceIDKey is the default key Context.Envelope reads the trace id from.
+const TraceIDKey = "trace_id"
+
 type ContextKeyType int
 
 const ContextRequestKey ContextKeyType = 0
@@ -800,6 +806,11 @@ func (c *Context) BindHeader(obj any) error {
 	return c.MustBindWith(obj, binding.Header)
 }
 
+// BindClaims is a shortcut for c.MustBindWith(obj, binding.Claims).
+func (c *Context) BindClaims(obj any) error {
+	return c.MustBindWith(obj, binding.Claims)
+}
+
 // BindUri binds the passed struct pointer using binding.Uri.
 // It will abort the request with HTTP 400 if any error occurs.
 func (c *Context) BindUri(obj any) error {
@@ -898,6 +909,11 @@ func (c *Context) ShouldBindTOML(obj any) error {
 	return c.ShouldBindWith(obj, binding.TOML)
 }
 
+// ShouldBindCBOR is a shortcut for c.ShouldBindWith(obj, binding.CBOR).
+func (c *Context) ShouldBindCBOR(obj any) error {
+	return c.ShouldBindWith(obj, binding.CBOR)
+}
+
 // ShouldBindPlain is a shortcut for c.ShouldBindWith(obj, binding.Plain).
 // It works like ShouldBindJSON but binds plain text data from the request body.
 func (c *Context) ShouldBindPlain(obj any) error {
@@ -910,6 +926,12 @@ func (c *Context) ShouldBindHeader(obj any) error {
 	return c.ShouldBindWith(obj, binding.Header)
 }
 
+// ShouldBindClaims is a shortcut for c.ShouldBindWith(obj, binding.Claims).
+// It binds the validated JWT claims of the request using the `claim` tag.
+func (c *Context) ShouldBindClaims(obj any) error {
+	return c.ShouldBindWith(obj, binding.Claims)
+}
+
 // ShouldBindUri binds the passed struct pointer using the specified binding engine.
 // It works like ShouldBindJSON but binds parameters from the URI.
 func (c *Context) ShouldBindUri(obj any) error {
@@ -948,6 +970,42 @@ func (c *Context) ShouldBindBodyWith(obj any, bb binding.BindingBody) (err error
 	return bb.BindBody(body, obj)
 }
 
+// ShouldBindBodyCapture is like ShouldBindBodyWith but also returns the raw
+// request body, e.g. for audit logging. The body is read only once: it is
+// cached under BodyBytesKey and c.Request.Body is replaced with a reader over
+// the same bytes, so handlers further down the chain can still read it.
+func (c *Context) ShouldBindBodyCapture(obj any, bb binding.BindingBody) ([]byte, error) {
+	body, err := c.captureBody()
+	if err != nil {
+		return nil, err
+	}
+	return body, bb.BindBody(body, obj)
+}
+
+// RawBody returns the request body cached by ShouldBindBodyWith or
+// ShouldBindBodyCapture, and whether one was cached.
+func (c *Context) RawBody() ([]byte, bool) {
+	if cb, ok := c.Get(BodyBytesKey); ok {
+		if body, ok := cb.([]byte); ok {
+			return body, true
+		}
+	}
+	return nil, false
+}
+
+func (c *Context) captureBody() ([]byte, error) {
+	if body, ok := c.RawBody(); ok {
+		return body, nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.Set(BodyBytesKey, body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
 // ShouldBindBodyWithJSON is a shortcut for c.ShouldBindBodyWith(obj, binding.JSON).
 func (c *Context) ShouldBindBodyWithJSON(obj any) error {
 	return c.ShouldBindBodyWith(obj, binding.JSON)
@@ -1090,6 +1148,61 @@ func (c *Context) Header(key, value string) {
 	c.Writer.Header().Set(key, value)
 }
 
+// EarlyHints sends a 103 Early Hints informational response carrying a Link
+// header per given link (e.g. `</style.css>; rel=preload; as=style`), so the
+// client can start preloading before the final response is ready.
+// It is a no-op when the response header was already written or the client
+// speaks HTTP/1.0, which does not support informational responses.
+func (c *Context) EarlyHints(links []string) {
+	if len(links) == 0 || c.Writer.Written() || c.Request == nil || !c.Request.ProtoAtLeast(1, 1) {
+		return
+	}
+
+	w, ok := c.Writer.(interface{ Unwrap() http.ResponseWriter })
+	if !ok {
+		return
+	}
+	header := c.Writer.Header()
+	for _, link := range links {
+		header.Add("Link", link)
+	}
+	w.Unwrap().WriteHeader(http.StatusEarlyHints)
+}
+
+// SetPaginationLinks adds an RFC 8288 Link header pointing to the first,
+// previous, next and last pages, e.g. `<https://api/items?page=3>; rel="next"`.
+// Empty URLs are omitted and no header is added when all are empty.
+func (c *Context) SetPaginationLinks(first, prev, next, last string) {
+	links := make([]string, 0, 4)
+	for _, link := range [...]struct{ url, rel string }{
+		{first, "first"},
+		{prev, "prev"},
+		{next, "next"},
+		{last, "last"},
+	} {
+		if link.url != "" {
+			links = append(links, "<"+link.url+`>; rel="`+link.rel+`"`)
+		}
+	}
+	if len(links) > 0 {
+		c.Writer.Header().Add("Link", strings.Join(links, ", "))
+	}
+}
+
+// Push initiates an HTTP/2 server push of target, see http.Pusher.
+// It is a no-op returning nil when the connection does not support push,
+// e.g. over HTTP/1.x or when the client disabled it.
+func (c *Context) Push(target string, opts *http.PushOptions) error {
+	pusher := c.Writer.Pusher()
+	if pusher == nil {
+		return nil
+	}
+	if err := pusher.Push(target, opts); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
 // GetHeader returns value from request headers.
 func (c *Context) GetHeader(key string) string {
 	return c.requestHeader(key)
@@ -1223,6 +1336,65 @@ func (c *Context) PureJSON(code int, obj any) {
 	c.Render(code, render.PureJSON{Data: obj})
 }
 
+// JSONFields serializes the given struct as JSON into the response body,
+// keeping only the selected top-level or dotted nested fields, e.g. the
+// values of a `?fields=id,owner.name` query. Unknown fields are ignored and
+// no fields serializes obj in full.
+func (c *Context) JSONFields(code int, obj any, fields []string) {
+	c.Render(code, render.SparseJSON{Data: obj, Fields: fields})
+}
+
+// JSONSchema writes a JSON Schema (draft 2020-12) describing the type of obj
+// into the response body, see render.JSONSchema.
+// It also sets the Content-Type as "application/schema+json".
+func (c *Context) JSONSchema(code int, obj any) {
+	c.Render(code, render.JSONSchema{Data: obj})
+}
+
+// Envelope serializes the given struct wrapped in a standard success envelope
+// into the response body, e.g. `{"success":true,"data":obj,"error":null}`.
+// The envelope reports failure with err's message when err is not nil.
+// The request's trace id, read from the context value set with
+// Engine.EnvelopeTraceKey, is added when present.
+// Field names can be changed with Engine.EnvelopeFields.
+// It also sets the Content-Type as "application/json".
+func (c *Context) Envelope(code int, obj any, err error) {
+	envelope := render.Envelope{
+		Fields:  c.engine.envelopeFields,
+		Success: err == nil,
+		Data:    obj,
+		TraceID: c.envelopeTraceID(),
+	}
+	if err != nil {
+		envelope.Error = err
+	}
+	c.Render(code, envelope)
+}
+
+// envelopeTraceID looks up the trace id in the context keys, then in the request context.
+func (c *Context) envelopeTraceID() string {
+	key := c.engine.envelopeTraceKey
+	if key == nil {
+		return ""
+	}
+
+	var val any
+	if keyAsString, ok := key.(string); ok {
+		val, _ = c.Get(keyAsString)
+	}
+	if val == nil && c.Request != nil {
+		val = c.Request.Context().Value(key)
+	}
+
+	switch traceID := val.(type) {
+	case string:
+		return traceID
+	case fmt.Stringer:
+		return traceID.String()
+	}
+	return ""
+}
+
 // XML serializes the given struct as XML into the response body.
 // It also sets the Content-Type as "application/xml".
 func (c *Context) XML(code int, obj any) {
@@ -1239,11 +1411,28 @@ func (c *Context) TOML(code int, obj any) {
 	c.Render(code, render.TOML{Data: obj})
 }
 
+// CSV writes rows, a [][]string or a slice of structs, as CSV into the
+// response body. Struct columns are named by their `csv` tag.
+func (c *Context) CSV(code int, rows any) {
+	c.Render(code, render.CSV{Data: rows})
+}
+
+// CBOR serializes the given struct as CBOR into the response body.
+func (c *Context) CBOR(code int, obj any) {
+	c.Render(code, render.CBOR{Data: obj})
+}
+
 // ProtoBuf serializes the given struct as ProtoBuf into the response body.
 func (c *Context) ProtoBuf(code int, obj any) {
 	c.Render(code, render.ProtoBuf{Data: obj})
 }
 
+// Markdown renders the given Markdown source as sanitized HTML into the response body.
+// It also sets the Content-Type as "text/html".
+func (c *Context) Markdown(code int, source string) {
+	c.Render(code, render.Markdown{Source: source})
+}
+
 // String writes the given string into the response body.
 func (c *Context) String(code int, format string, values ...any) {
 	c.Render(code, render.String{Format: format, Data: values})
@@ -1258,6 +1447,40 @@ func (c *Context) Redirect(code int, location string) {
 	})
 }
 
+// RedirectPermanent answers with a 308 Permanent Redirect to location. Unlike
+// 301 and 302, clients replay the request to location with its original
+// method and body, so a POST stays a POST.
+func (c *Context) RedirectPermanent(location string) {
+	c.Redirect(http.StatusPermanentRedirect, location)
+}
+
+// RedirectTemporary answers with a 307 Temporary Redirect to location. Unlike
+// 302, clients replay the request to location with its original method and
+// body, so a POST stays a POST.
+func (c *Context) RedirectTemporary(location string) {
+	c.Redirect(http.StatusTemporaryRedirect, location)
+}
+
+// RateLimited answers with 429 Too Many Requests, a Retry-After header of
+// retryAfter, the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset
+// headers of info and a JSON error body. Middleware should also Abort.
+func (c *Context) RateLimited(retryAfter time.Duration, info render.RateLimitInfo) {
+	c.Render(http.StatusTooManyRequests, render.RateLimited{RetryAfter: retryAfter, Info: info})
+}
+
+// Created answers with 201 Created, the Location of the new resource and its
+// JSON representation. It panics when location is empty.
+func (c *Context) Created(location string, obj any) {
+	assert1(location != "", "a created resource needs a location")
+	c.Render(http.StatusCreated, render.Representation{Location: location, Data: obj})
+}
+
+// Updated answers with 200 OK and the JSON representation of the updated
+// resource, with Content-Location set to the request path.
+func (c *Context) Updated(obj any) {
+	c.Render(http.StatusOK, render.Representation{ContentLocation: c.Request.URL.Path, Data: obj})
+}
+
 // Data writes some data into the body stream and updates the HTTP code.
 func (c *Context) Data(code int, contentType string, data []byte) {
 	c.Render(code, render.Data{
@@ -1266,14 +1489,77 @@ func (c *Context) Data(code int, contentType string, data []byte) {
 	})
 }
 
+// Bytes writes pre-serialized bytes data into the body stream together with
+// an ETag computed from the data. For 200 responses the request's conditional
+// headers are evaluated against the ETag, see render.Conditional.
+func (c *Context) Bytes(code int, r render.Bytes) {
+	if code == http.StatusOK {
+		c.Render(code, render.ConditionalRender{Body: r, Request: c.Request, ETag: r.ETag()})
+		return
+	}
+	c.Render(code, r)
+}
+
+// FramedBinary writes every message received from messages as a length-prefixed
+// binary frame, flushing after each frame, until messages is closed.
+// It also sets the Content-Type as "application/octet-stream".
+func (c *Context) FramedBinary(code int, messages <-chan []byte) {
+	c.Render(code, render.FramedBinary{Messages: messages})
+}
+
+// NDJSONStream writes every record received from records as a line of JSON
+// (newline-delimited JSON), flushing after each record, until records is closed
+// or the client disconnected. Records that fail to marshal are skipped and
+// pushed to c.Errors. It also sets the Content-Type as "application/x-ndjson".
+func (c *Context) NDJSONStream(code int, records <-chan any) {
+	c.NDJSONStreamWithFlush(code, render.FlushEvery{}, records)
+}
+
+// NDJSONStreamWithFlush is like NDJSONStream but only flushes once the given
+// number of bytes or records were written, instead of after every record.
+func (c *Context) NDJSONStreamWithFlush(code int, every render.FlushEvery, records <-chan any) {
+	r := render.NDJSON{Records: records, FlushEvery: every}
+	if c.Request != nil {
+		r.Context = c.Request.Context()
+	}
+	c.Render(code, r)
+}
+
+// ProtoStream writes every message received from messages length-delimited
+// (varint length prefix followed by the message bytes), flushing after each
+// message, until messages is closed.
+// It also sets the Content-Type as "application/x-protobuf-stream".
+func (c *Context) ProtoStream(code int, messages <-chan proto.Message) {
+	c.Render(code, render.ProtoStream{Messages: messages})
+}
+
+// Proxy streams the upstream response to the client with its status code, headers
+// (except hop-by-hop headers) and body. The upstream body is closed afterwards.
+// Use render.Proxy directly to filter the forwarded headers.
+func (c *Context) Proxy(upstream *http.Response) {
+	defer upstream.Body.Close()
+	c.Render(upstream.StatusCode, render.Proxy{Upstream: upstream})
+}
+
 // DataFromReader writes the specified reader into the body stream and updates the HTTP code.
+// When reader is an io.ReadSeeker, the request's Range header is honored for 200 responses.
+// An "ETag" and "Last-Modified" in extraHeaders are evaluated against the request's
+// conditional headers for 200 responses, see render.Conditional. The content is sent
+// as is with contentLength; render a render.Reader with AcceptEncoding set to gzip it.
 func (c *Context) DataFromReader(code int, contentLength int64, contentType string, reader io.Reader, extraHeaders map[string]string) {
-	c.Render(code, render.Reader{
+	r := render.Reader{
 		Headers:       extraHeaders,
 		ContentType:   contentType,
 		ContentLength: contentLength,
 		Reader:        reader,
-	})
+	}
+	if code == http.StatusOK && c.Request != nil {
+		r.Range = c.requestHeader("Range")
+		r.ETag = extraHeaders["ETag"]
+		r.LastModified, _ = http.ParseTime(extraHeaders["Last-Modified"])
+		r.Request = c.Request
+	}
+	c.Render(code, r)
 }
 
 // File writes the specified file into the body stream in an efficient way.
@@ -1317,6 +1603,13 @@ func (c *Context) SSEvent(name string, message any) {
 	})
 }
 
+// SSEStream writes every event received from events as a Server-Sent Event,
+// flushing after each event, until events is closed. When keepAlive is set, a
+// keep-alive comment is sent whenever no event was written for that long.
+func (c *Context) SSEStream(keepAlive time.Duration, events <-chan sse.Event) {
+	c.Render(-1, render.SSEStream{Events: events, KeepAlive: keepAlive})
+}
+
 // Stream sends a streaming response and returns a boolean
 // indicates "Is client disconnected in middle of stream"
 func (c *Context) Stream(step func(w io.Writer) bool) bool {
@@ -1336,6 +1629,44 @@ func (c *Context) Stream(step func(w io.Writer) bool) bool {
 	}
 }
 
+// StreamWithFlush is like Stream but only flushes once the given number of
+// bytes or records (steps) were written, instead of after every step.
+// The writer passed to step is still a ResponseWriter, but only bytes written
+// through it count toward every.Bytes; writes through c.Writer or c.SSEvent
+// do not.
+func (c *Context) StreamWithFlush(every render.FlushEvery, step func(w io.Writer) bool) bool {
+	w := streamWriter{ResponseWriter: c.Writer, flush: render.NewFlushWriter(c.Writer, every)}
+	clientGone := c.Writer.CloseNotify()
+	for {
+		select {
+		case <-clientGone:
+			return true
+		default:
+			keepOpen := step(w)
+			w.flush.EndRecord()
+			if !keepOpen {
+				w.flush.Flush()
+				return false
+			}
+		}
+	}
+}
+
+// streamWriter is the ResponseWriter handed to the steps of StreamWithFlush,
+// its writes are counted by flush.
+type streamWriter struct {
+	ResponseWriter
+	flush *render.FlushWriter
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	return w.flush.Write(p)
+}
+
+func (w streamWriter) WriteString(s string) (int, error) {
+	return w.flush.Write([]byte(s))
+}
+
 /************************************/
 /******** CONTENT NEGOTIATION *******/
 /************************************/
@@ -1354,7 +1685,9 @@ type Negotiate struct {
 }
 
 // Negotiate calls different Render according to acceptable Accept format.
+// Accept is added to the Vary header of the response.
 func (c *Context) Negotiate(code int, config Negotiate) {
+	c.Vary("Accept")
 	switch c.NegotiateFormat(config.Offered...) {
 	case binding.MIMEJSON:
 		data := chooseData(config.JSONData, config.Data)
@@ -1385,6 +1718,23 @@ func (c *Context) Negotiate(code int, config Negotiate) {
 	}
 }
 
+// NegotiateRender renders obj in the format of render.DefaultNegotiator that
+// best matches the Accept header by q-value, e.g. CBOR for `Accept:
+// application/cbor;q=0.9, application/json;q=0.8`, falling back to JSON when
+// nothing matches. Accept is added to the Vary header of the response.
+func (c *Context) NegotiateRender(code int, obj any) {
+	c.Vary("Accept")
+	c.Render(code, render.DefaultNegotiator.Render(c.requestHeader("Accept"), obj))
+}
+
+// Vary adds the given request header names to the Vary header of the
+// response, skipping names that are already listed. Middlewares choosing the
+// response by a request header, e.g. compression by Accept-Encoding, should
+// call it so caches key on that header.
+func (c *Context) Vary(fields ...string) {
+	render.AddVary(c.Writer.Header(), fields...)
+}
+
 // NegotiateFormat returns an acceptable Accept format.
 func (c *Context) NegotiateFormat(offered ...string) string {
 	assert1(len(offered) > 0, "you must provide at least one offer")
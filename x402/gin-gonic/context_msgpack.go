@@ -0,0 +1,20 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+//go:build !nomsgpack
+
+package gin
+
+import "github.com/gin-gonic/gin/render"
+
+// MsgPack serializes the given struct as MessagePack into the response body.
+// It also sets the Content-Type as "application/msgpack".
+func (c *Context) MsgPack(code int, obj any) {
+	c.Render(code, render.MsgPack{Data: obj})
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
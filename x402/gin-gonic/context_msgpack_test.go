@@ -0,0 +1,36 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+//go:build !nomsgpack
+
+package gin
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+func TestContextRenderMsgPack(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+
+	c.MsgPack(http.StatusCreated, H{"foo": "bar"})
+
+	var got map[string]string
+	require.NoError(t, codec.NewDecoder(bytes.NewReader(w.Body.Bytes()), new(codec.MsgpackHandle)).Decode(&got))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "bar", got["foo"])
+	assert.Equal(t, "application/msgpack; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
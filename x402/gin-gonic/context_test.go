@@ -18,6 +18,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -28,9 +30,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/gin-gonic/gin/codec/json"
+	"github.com/gin-gonic/gin/render"
 	testdata "github.com/gin-gonic/gin/testdata/protoexample"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1206,6 +1210,47 @@ func TestContextRenderSecureJSON(t *testing.T) {
 	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
 }
 
+// Tests that the response is wrapped in an envelope
+// using the field names configured on the engine
+func TestContextRenderEnvelope(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, router := CreateTestContext(w)
+
+	router.EnvelopeFields(render.EnvelopeFields{Data: "result"})
+	c.Envelope(http.StatusOK, []string{"foo"}, nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"success":true,"result":["foo"],"error":null}`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = httptest.NewRecorder()
+	c, _ = CreateTestContext(w)
+	c.Envelope(http.StatusBadRequest, nil, errors.New("bad input"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `{"success":false,"data":null,"error":"bad input"}`, w.Body.String())
+}
+
+func TestContextRenderEnvelopeTraceID(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+
+	c.Set(TraceIDKey, "trace-123")
+	c.Envelope(http.StatusOK, "foo", nil)
+
+	assert.Equal(t, `{"success":true,"data":"foo","error":null,"trace_id":"trace-123"}`, w.Body.String())
+
+	type traceKey struct{}
+	w = httptest.NewRecorder()
+	c, router := CreateTestContext(w)
+	router.EnvelopeFields(render.EnvelopeFields{TraceID: "traceId"}).EnvelopeTraceKey(traceKey{})
+	c.Request, _ = http.NewRequestWithContext(
+		context.WithValue(context.Background(), traceKey{}, "trace-456"), http.MethodGet, "/", nil)
+	c.Envelope(http.StatusOK, "foo", nil)
+
+	assert.Equal(t, `{"success":true,"data":"foo","error":null,"traceId":"trace-456"}`, w.Body.String())
+}
+
 // Tests that no Custom JSON is rendered if code is 204
 func TestContextRenderNoContentSecureJSON(t *testing.T) {
 	w := httptest.NewRecorder()
@@ -1385,6 +1430,52 @@ func TestContextRenderData(t *testing.T) {
 	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
 }
 
+func TestContextRenderBytes(t *testing.T) {
+	router := New()
+	cached := render.NewBytes("application/json; charset=utf-8", []byte(`{"foo":"bar"}`))
+	router.GET("/", func(c *Context) {
+		c.Bytes(http.StatusOK, cached)
+	})
+
+	w := PerformRequest(router, http.MethodGet, "/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"foo":"bar"}`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	w = PerformRequest(router, http.MethodGet, "/", header{Key: "If-None-Match", Value: etag})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	w = PerformRequest(router, http.MethodGet, "/", header{Key: "If-None-Match", Value: `"stale"`})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"foo":"bar"}`, w.Body.String())
+
+	w = PerformRequest(router, http.MethodGet, "/", header{Key: "If-Match", Value: `"stale"`})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestContextRenderProxy(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+
+	c.Proxy(&http.Response{
+		StatusCode:    http.StatusAccepted,
+		ContentLength: 3,
+		Header:        http.Header{"Content-Type": {"text/plain"}, "Connection": {"close"}},
+		Body:          io.NopCloser(strings.NewReader("foo")),
+	})
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "foo", w.Body.String())
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, "3", w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Header().Get("Connection"))
+}
+
 // Tests that no Custom Data is rendered if code is 204
 func TestContextRenderNoContentData(t *testing.T) {
 	w := httptest.NewRecorder()
@@ -1509,6 +1600,94 @@ func TestContextRenderTOML(t *testing.T) {
 	assert.Equal(t, "application/toml; charset=utf-8", w.Header().Get("Content-Type"))
 }
 
+func TestContextRenderJSONFields(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?fields=id,meta.tag", nil)
+
+	c.JSONFields(http.StatusOK, H{"id": 1, "name": "gin", "meta": H{"tag": "web", "stars": 5}}, c.QueryArray("fields"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":1,"meta":{"tag":"web"}}`, w.Body.String())
+}
+
+func TestContextNegotiateRender(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Accept", "application/cbor;q=0.9, application/json;q=0.8")
+
+	c.NegotiateRender(http.StatusOK, H{"foo": "bar"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/cbor", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	w = httptest.NewRecorder()
+	c, _ = CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Accept", "image/png")
+
+	c.NegotiateRender(http.StatusOK, H{"foo": "bar"})
+
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"foo":"bar"}`, w.Body.String())
+}
+
+func TestContextRenderCSV(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+
+	c.CSV(http.StatusOK, [][]string{{"id", "name"}, {"1", "gin"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "id,name\n1,gin\n", w.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
+// TestContextRenderCBOR tests that the response is serialized as CBOR
+// and Content-Type is set to application/cbor
+func TestContextRenderCBOR(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+
+	c.CBOR(http.StatusCreated, H{"foo": "bar"})
+
+	var got map[string]string
+	require.NoError(t, cbor.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, map[string]string{"foo": "bar"}, got)
+	assert.Equal(t, "application/cbor", w.Header().Get("Content-Type"))
+}
+
+func TestContextRenderCreated(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/items", nil)
+
+	c.Created("/items/42", H{"id": 42, "name": "widget"})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/items/42", w.Header().Get("Location"))
+	assert.JSONEq(t, `{"id":42,"name":"widget"}`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	assert.Panics(t, func() { c.Created("", H{}) })
+}
+
+func TestContextRenderUpdated(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/items/42", nil)
+
+	c.Updated(H{"id": 42})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+	assert.Equal(t, "/items/42", w.Header().Get("Content-Location"))
+	assert.JSONEq(t, `{"id":42}`, w.Body.String())
+}
+
 // TestContextRenderProtoBuf tests that the response is serialized as ProtoBuf
 // and Content-Type is set to application/x-protobuf
 // and we just use the example protobuf to check if the response is correct
@@ -1549,6 +1728,83 @@ func TestContextHeaders(t *testing.T) {
 	assert.False(t, exist)
 }
 
+func TestContextEarlyHints(t *testing.T) {
+	router := New()
+	router.GET("/", func(c *Context) {
+		c.EarlyHints([]string{"</style.css>; rel=preload; as=style"})
+		c.String(http.StatusOK, "ok")
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	var events []string
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			events = append(events, fmt.Sprintf("%d %s", code, header.Get("Link")))
+			return nil
+		},
+	}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(context.Background(), trace), http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	events = append(events, strconv.Itoa(resp.StatusCode))
+
+	assert.Equal(t, []string{"103 </style.css>; rel=preload; as=style", "200"}, events)
+}
+
+func TestContextEarlyHintsAfterWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	c.String(http.StatusOK, "ok")
+	c.EarlyHints([]string{"</style.css>; rel=preload"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Link"))
+}
+
+func TestContextSetPaginationLinks(t *testing.T) {
+	c, _ := CreateTestContext(httptest.NewRecorder())
+	c.SetPaginationLinks("/items?page=1", "", "/items?page=3", "/items?page=9")
+
+	assert.Equal(t, []string{`</items?page=1>; rel="first", </items?page=3>; rel="next", </items?page=9>; rel="last"`}, c.Writer.Header().Values("Link"))
+
+	c, _ = CreateTestContext(httptest.NewRecorder())
+	c.SetPaginationLinks("", "", "", "")
+	assert.Empty(t, c.Writer.Header().Values("Link"))
+}
+
+type recordingPusher struct {
+	http.ResponseWriter
+	targets []string
+	err     error
+}
+
+func (p *recordingPusher) Push(target string, opts *http.PushOptions) error {
+	p.targets = append(p.targets, target)
+	return p.err
+}
+
+func TestContextPush(t *testing.T) {
+	pusher := &recordingPusher{ResponseWriter: httptest.NewRecorder()}
+	c, _ := CreateTestContext(pusher)
+	require.NoError(t, c.Push("/style.css", nil))
+	assert.Equal(t, []string{"/style.css"}, pusher.targets)
+
+	pusher.err = http.ErrNotSupported
+	require.NoError(t, c.Push("/app.js", nil))
+	pusher.err = errors.New("push failed")
+	require.Error(t, c.Push("/app.js", nil))
+
+	// HTTP/1 writers do not push
+	c, _ = CreateTestContext(httptest.NewRecorder())
+	require.NoError(t, c.Push("/style.css", &http.PushOptions{Method: http.MethodGet}))
+}
+
 // TODO
 func TestContextRenderRedirectWithRelativePath(t *testing.T) {
 	w := httptest.NewRecorder()
@@ -1588,6 +1844,35 @@ func TestContextRenderRedirectWith201(t *testing.T) {
 	assert.Equal(t, "/resource", w.Header().Get("Location"))
 }
 
+func TestContextRedirectMethodPreserving(t *testing.T) {
+	router := New()
+	router.POST("/old", func(c *Context) {
+		c.RedirectPermanent("/new")
+	})
+	router.POST("/moved", func(c *Context) {
+		c.RedirectTemporary("/new")
+	})
+	router.POST("/new", func(c *Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "%s %s", c.Request.Method, body)
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	for path, code := range map[string]int{"/old": http.StatusPermanentRedirect, "/moved": http.StatusTemporaryRedirect} {
+		w := PerformRequest(router, http.MethodPost, path)
+		assert.Equal(t, code, w.Code)
+		assert.Equal(t, "/new", w.Header().Get("Location"))
+
+		resp, err := http.Post(server.URL+path, MIMEPlain, strings.NewReader("payload"))
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "POST payload", string(body))
+	}
+}
+
 func TestContextRenderRedirectAll(t *testing.T) {
 	c, _ := CreateTestContext(httptest.NewRecorder())
 	c.Request, _ = http.NewRequest(http.MethodPost, "http://example.com", nil)
@@ -1644,6 +1929,32 @@ func TestContextNegotiationWithYAML(t *testing.T) {
 	assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
 }
 
+func TestContextNegotiationVary(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Add("Accept", MIMEJSON)
+
+	// The handler picks a format itself, then a compression middleware marks
+	// the response before Negotiate adds Accept again.
+	c.Vary("Accept")
+	c.Vary("accept-encoding")
+	c.Vary("Accept-Encoding")
+	c.Negotiate(http.StatusOK, Negotiate{
+		Offered: []string{MIMEJSON, MIMEXML},
+		Data:    H{"foo": "bar"},
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"Accept, Accept-Encoding"}, w.Result().Header.Values("Vary"))
+
+	w = httptest.NewRecorder()
+	c, _ = CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+	c.Negotiate(http.StatusOK, Negotiate{Offered: []string{MIMEJSON}, Data: H{}})
+	assert.Equal(t, "Accept", w.Result().Header.Get("Vary"))
+}
+
 func TestContextNegotiationWithTOML(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := CreateTestContext(w)
@@ -2442,6 +2753,47 @@ func TestContextShouldBindWithTOML(t *testing.T) {
 	assert.Equal(t, 0, w.Body.Len())
 }
 
+func TestContextShouldBindWithCBOR(t *testing.T) {
+	type payload struct {
+		Foo string `cbor:"foo" binding:"required"`
+		Bar int    `cbor:"bar"`
+	}
+	body, err := cbor.Marshal(payload{Foo: "bar", Bar: 7})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	c.Request.Header.Add("Content-Type", MIMECBOR)
+
+	var obj payload
+	require.NoError(t, c.ShouldBind(&obj))
+	assert.Equal(t, payload{Foo: "bar", Bar: 7}, obj)
+	assert.Equal(t, 0, w.Body.Len())
+}
+
+func TestContextShouldBindJSONSliceValidation(t *testing.T) {
+	type item struct {
+		Name string `json:"name" binding:"required"`
+	}
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"name":"a"},{},{"name":"c"},{}]`))
+	c.Request.Header.Add("Content-Type", MIMEJSON)
+
+	var items []item
+	err := c.ShouldBindJSON(&items)
+	var sliceErr binding.SliceValidationError
+	require.ErrorAs(t, err, &sliceErr)
+
+	indices := make([]int, 0, 2)
+	for _, elem := range sliceErr.Elements() {
+		indices = append(indices, elem.Index)
+		assert.Contains(t, elem.Err.Error(), "'Name' failed on the 'required' tag")
+	}
+	assert.Equal(t, []int{1, 3}, indices)
+}
+
 func TestContextBadAutoShouldBind(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := CreateTestContext(w)
@@ -2540,6 +2892,50 @@ func TestContextShouldBindBodyWith(t *testing.T) {
 	}
 }
 
+func TestContextShouldBindBodyCapture(t *testing.T) {
+	type typeA struct {
+		Foo string `json:"foo" binding:"required"`
+	}
+	body := `{"foo": "FOO",  "extra": [1, 2]}`
+
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(body))
+
+	_, ok := c.RawBody()
+	assert.False(t, ok)
+
+	var obj typeA
+	raw, err := c.ShouldBindBodyCapture(&obj, binding.JSON)
+	require.NoError(t, err)
+	assert.Equal(t, typeA{"FOO"}, obj)
+	assert.Equal(t, body, string(raw))
+
+	cached, ok := c.RawBody()
+	require.True(t, ok)
+	assert.Equal(t, body, string(cached))
+
+	// The request body is still readable after binding.
+	reread, err := io.ReadAll(c.Request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(reread))
+
+	// A second capture reuses the cached bytes.
+	var again typeA
+	raw, err = c.ShouldBindBodyCapture(&again, binding.JSON)
+	require.NoError(t, err)
+	assert.Equal(t, typeA{"FOO"}, again)
+	assert.Equal(t, body, string(raw))
+
+	// Binding failures still return the captured body.
+	c, _ = CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(`{}`))
+	var missing typeA
+	raw, err = c.ShouldBindBodyCapture(&missing, binding.JSON)
+	require.Error(t, err)
+	assert.Equal(t, `{}`, string(raw))
+}
+
 func TestContextShouldBindBodyWithJSON(t *testing.T) {
 	for _, tt := range []struct {
 		name        string
@@ -2967,6 +3363,54 @@ func TestContextRenderDataFromReaderNoHeaders(t *testing.T) {
 	assert.Equal(t, strconv.FormatInt(contentLength, 10), w.Header().Get("Content-Length"))
 }
 
+func TestContextRenderDataFromReaderETag(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("If-None-Match", `"abc"`)
+
+	c.DataFromReader(http.StatusOK, 4, "text/plain", strings.NewReader("data"), map[string]string{"ETag": `"abc"`})
+	c.Writer.WriteHeaderNow()
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+}
+
+func TestContextRenderNDJSONStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	records := make(chan any, 2)
+	records <- H{"id": 1}
+	records <- func() {}
+	close(records)
+	c.NDJSONStream(http.StatusOK, records)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{\"id\":1}\n", w.Body.String())
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Len(t, c.Errors, 1)
+}
+
+func TestContextRenderNDJSONStreamWithFlush(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	records := make(chan any, 3)
+	for i := range 3 {
+		records <- H{"id": i}
+	}
+	close(records)
+	c.NDJSONStreamWithFlush(http.StatusOK, render.FlushEvery{Records: 2}, records)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{\"id\":0}\n{\"id\":1}\n{\"id\":2}\n", w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
 type TestResponseRecorder struct {
 	*httptest.ResponseRecorder
 	closeChannel chan bool
@@ -3006,6 +3450,42 @@ func TestContextStream(t *testing.T) {
 	assert.Equal(t, "testtest", w.Body.String())
 }
 
+func TestContextStreamWithFlush(t *testing.T) {
+	w := CreateTestResponseRecorder()
+	c, _ := CreateTestContext(w)
+
+	steps := 0
+	c.StreamWithFlush(render.FlushEvery{Records: 3}, func(w io.Writer) bool {
+		steps++
+		_, err := w.Write([]byte("test"))
+		require.NoError(t, err)
+
+		return steps < 5
+	})
+
+	assert.Equal(t, strings.Repeat("test", 5), w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
+func TestContextStreamWithFlushResponseWriter(t *testing.T) {
+	w := CreateTestResponseRecorder()
+	c, _ := CreateTestContext(w)
+
+	c.StreamWithFlush(render.FlushEvery{Bytes: 8}, func(w io.Writer) bool {
+		rw, ok := w.(ResponseWriter)
+		require.True(t, ok)
+		rw.Header().Set("X-Stream", "yes")
+		_, err := rw.WriteString("test")
+		require.NoError(t, err)
+
+		return false
+	})
+
+	assert.Equal(t, "yes", w.Header().Get("X-Stream"))
+	assert.Equal(t, "test", w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
 func TestContextStreamWithClientGone(t *testing.T) {
 	w := CreateTestResponseRecorder()
 	c, _ := CreateTestContext(w)
@@ -3737,5 +4217,35 @@ func BenchmarkGetMapFromFormData(b *testing.B) {
 	}
 }
 
+func TestContextRateLimited(t *testing.T) {
+	router := New()
+	router.GET("/limited", func(c *Context) {
+		c.RateLimited(30*time.Second, render.RateLimitInfo{Limit: 10, Remaining: 0, Reset: 30 * time.Second})
+	})
+
+	w := PerformRequest(router, http.MethodGet, "/limited")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, "10", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"rate limit exceeded","retryAfter":30}`, w.Body.String())
+}
+
+func TestContextDataFromReaderNoCompression(t *testing.T) {
+	router := New()
+	body := strings.Repeat("a,b,c\n", 100)
+	router.GET("/", func(c *Context) {
+		c.DataFromReader(http.StatusOK, int64(len(body)), "text/csv", strings.NewReader(body), nil)
+	})
+
+	w := PerformRequest(router, http.MethodGet, "/", header{Key: "Accept-Encoding", Value: "gzip"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, strconv.Itoa(len(body)), w.Header().Get("Content-Length"))
+	assert.Equal(t, body, w.Body.String())
+}
+
 
 /* universal-crypto-mcp © nicholas */
\ No newline at end of file
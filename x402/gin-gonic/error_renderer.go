@@ -0,0 +1,78 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin/render"
+)
+
+// Content-Type MIME of RFC 9457 problem details.
+const (
+	MIMEProblemJSON = "application/problem+json"
+	MIMEProblemXML  = "application/problem+xml"
+)
+
+// defaultErrorFormats are the formats offered by ErrorRenderer unless set with Engine.ErrorFormats.
+var defaultErrorFormats = []string{MIMEProblemJSON, MIMEJSON, MIMEXML}
+
+// ErrorRenderer returns a middleware that renders the errors recorded with
+// Context.Error once the handlers chain returns, unless a response was
+// already written. The body is a problem details object in the format
+// negotiated from the engine's ErrorFormats. The response status is kept when
+// it is an error status and is 500 otherwise. Only public errors are
+// disclosed in the detail member.
+func ErrorRenderer() HandlerFunc {
+	return func(c *Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		c.renderErrors()
+	}
+}
+
+func (c *Context) renderErrors() {
+	code := c.Writer.Status()
+	if code < http.StatusBadRequest {
+		code = http.StatusInternalServerError
+	}
+	problem := render.ProblemDetails{
+		Title:  http.StatusText(code),
+		Status: code,
+		Detail: strings.Join(c.Errors.ByType(ErrorTypePublic).Errors(), "; "),
+	}
+	if c.Request != nil && c.Request.URL != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+
+	formats := defaultErrorFormats
+	if c.engine != nil && len(c.engine.errorFormats) > 0 {
+		formats = c.engine.errorFormats
+	}
+	// An error body is sent even when no format is acceptable
+	format := c.NegotiateFormat(formats...)
+	if format == "" {
+		format = formats[0]
+	}
+
+	switch format {
+	case MIMEJSON:
+		c.JSON(code, problem)
+	case MIMEXML, MIMEXML2:
+		c.XML(code, problem)
+	case MIMEProblemXML:
+		c.Render(code, render.ProblemXML{Data: problem})
+	default:
+		c.Render(code, render.Problem{Data: problem})
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
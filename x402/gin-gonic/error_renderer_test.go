@@ -0,0 +1,75 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorRenderer(t *testing.T) {
+	router := New()
+	router.Use(ErrorRenderer())
+	router.GET("/missing", func(c *Context) {
+		c.Status(http.StatusNotFound)
+		c.Error(errors.New("item 42 not found")).SetType(ErrorTypePublic) //nolint: errcheck
+	})
+	router.GET("/private", func(c *Context) {
+		c.Error(errors.New("database password leaked")) //nolint: errcheck
+	})
+	router.GET("/written", func(c *Context) {
+		c.String(http.StatusOK, "ok")
+		c.Error(errors.New("after write")) //nolint: errcheck
+	})
+
+	w := PerformRequest(router, http.MethodGet, "/missing")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"title":"Not Found","status":404,"detail":"item 42 not found","instance":"/missing"}`, w.Body.String())
+
+	w = PerformRequest(router, http.MethodGet, "/missing", header{"Accept", "application/xml"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `<problem xmlns="urn:ietf:rfc:7807"><title>Not Found</title><status>404</status><detail>item 42 not found</detail><instance>/missing</instance></problem>`, w.Body.String())
+
+	w = PerformRequest(router, http.MethodGet, "/private", header{"Accept", "application/json"})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"title":"Internal Server Error","status":500,"instance":"/private"}`, w.Body.String())
+
+	// unacceptable formats still get an error body
+	w = PerformRequest(router, http.MethodGet, "/private", header{"Accept", "text/csv"})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+
+	w = PerformRequest(router, http.MethodGet, "/written")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ok", w.Body.String())
+}
+
+func TestErrorRendererFormats(t *testing.T) {
+	router := New()
+	router.ErrorFormats(MIMEProblemXML)
+	router.Use(ErrorRenderer())
+	router.GET("/", func(c *Context) {
+		c.Status(http.StatusBadRequest)
+		c.Error(errors.New("bad input")).SetType(ErrorTypePublic) //nolint: errcheck
+	})
+
+	w := PerformRequest(router, http.MethodGet, "/", header{"Accept", "application/json"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, MIMEProblemXML, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<detail>bad input</detail>")
+
+	assert.Panics(t, func() { router.ErrorFormats() })
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -179,11 +179,19 @@ type Engine struct {
 	// UseH2C enable h2c support.
 	UseH2C bool
 
+	// StaticGzipMinSize gzips files served by Static and StaticFS that are
+	// compressible and at least this many bytes long, when the client accepts
+	// gzip. Zero disables compression.
+	StaticGzipMinSize int64
+
 	// ContextWithFallback enable fallback Context.Deadline(), Context.Done(), Context.Err() and Context.Value() when Context.Request.Context() is not nil.
 	ContextWithFallback bool
 
 	delims           render.Delims
 	secureJSONPrefix string
+	envelopeFields   render.EnvelopeFields
+	envelopeTraceKey any
+	errorFormats     []string
 	HTMLRender       render.HTMLRender
 	FuncMap          template.FuncMap
 	allNoRoute       HandlersChain
@@ -232,6 +240,8 @@ func New(opts ...OptionFunc) *Engine {
 		trees:                  make(methodTrees, 0, 9),
 		delims:                 render.Delims{Left: "{{", Right: "}}"},
 		secureJSONPrefix:       "while(1);",
+		envelopeFields:         render.DefaultEnvelopeFields,
+		envelopeTraceKey:       TraceIDKey,
 		trustedProxies:         []string{"0.0.0.0/0", "::/0"},
 		trustedCIDRs:           defaultTrustedCIDRs,
 	}
@@ -277,6 +287,29 @@ func (engine *Engine) SecureJsonPrefix(prefix string) *Engine {
 	return engine
 }
 
+// EnvelopeFields sets the field names used in Context.Envelope.
+func (engine *Engine) EnvelopeFields(fields render.EnvelopeFields) *Engine {
+	engine.envelopeFields = fields
+	return engine
+}
+
+// EnvelopeTraceKey sets the context key Context.Envelope reads the trace id from.
+// String keys are looked up in Context.Keys first, then in the request context.
+// A nil key disables the trace id.
+func (engine *Engine) EnvelopeTraceKey(key any) *Engine {
+	engine.envelopeTraceKey = key
+	return engine
+}
+
+// ErrorFormats sets the formats ErrorRenderer negotiates error bodies from, in
+// order of preference. Supported formats are MIMEProblemJSON, MIMEProblemXML,
+// MIMEJSON and MIMEXML. The default is MIMEProblemJSON, MIMEJSON and MIMEXML.
+func (engine *Engine) ErrorFormats(formats ...string) *Engine {
+	assert1(len(formats) > 0, "you must provide at least one error format")
+	engine.errorFormats = formats
+	return engine
+}
+
 // LoadHTMLGlob loads HTML files identified by glob pattern
 // and associates the result with HTML renderer.
 func (engine *Engine) LoadHTMLGlob(pattern string) {
@@ -314,7 +347,7 @@ func (engine *Engine) LoadHTMLFS(fs http.FileSystem, patterns ...string) {
 	}
 
 	templ := template.Must(template.New("").Delims(engine.delims.Left, engine.delims.Right).Funcs(engine.FuncMap).ParseFS(
-		filesystem.FileSystem{FileSystem: fs}, patterns...))
+		filesystem.FileSystem{FileSystem: fs, AllowListing: true}, patterns...))
 	engine.SetHTMLTemplate(templ)
 }
 
@@ -13,23 +13,80 @@ package fs
 import (
 	"io/fs"
 	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
 )
 
 // FileSystem implements an [fs.FS].
 type FileSystem struct {
 	http.FileSystem
+	// AllowListing lets directories be opened, e.g. to glob templates.
+	AllowListing bool
 }
 
-// FIXME(nich): review edge cases
 // Open passes `Open` to the upstream implementation and return an [fs.File].
+// Names with `..` segments, paths escaping an [http.Dir] root through
+// symlinks and, unless AllowListing is set, directories are reported as
+// [fs.ErrNotExist].
 func (o FileSystem) Open(name string) (fs.File, error) {
+	if hasTraversal(name) {
+		return nil, notExist(name)
+	}
+	if dir, ok := o.FileSystem.(http.Dir); ok {
+		if err := checkWithinDir(string(dir), name); err != nil {
+			return nil, err
+		}
+	}
+
 	f, err := o.FileSystem.Open(name)
 	if err != nil {
 		return nil, err
 	}
+	if !o.AllowListing {
+		if info, err := f.Stat(); err == nil && info.IsDir() {
+			f.Close()
+			return nil, notExist(name)
+		}
+	}
 
 	return fs.File(f), nil
 }
 
+// hasTraversal reports whether name has a `..` segment, with either separator.
+func hasTraversal(name string) bool {
+	for _, segment := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWithinDir makes sure name, once symlinks are resolved, stays inside root.
+func checkWithinDir(root, name string) error {
+	if root == "" {
+		root = "."
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	target, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(path.Clean("/"+name))))
+	if err != nil {
+		// missing files are reported by the upstream Open
+		return nil
+	}
+	rel, err := filepath.Rel(resolvedRoot, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return notExist(name)
+	}
+	return nil
+}
+
+func notExist(name string) error {
+	return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
 
 /* ucm:n1ch31bd0562 */
\ No newline at end of file
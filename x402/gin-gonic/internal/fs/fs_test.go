@@ -4,8 +4,11 @@ package fs
 
 import (
 	"errors"
+	"io"
+	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,7 +31,7 @@ func TestFileSystem_Open(t *testing.T) {
 			return testFile, nil
 		},
 	}
-	fs := &FileSystem{mockFS}
+	fs := &FileSystem{FileSystem: mockFS}
 
 	file, err := fs.Open("foo")
 
@@ -43,7 +46,7 @@ func TestFileSystem_Open_err(t *testing.T) {
 			return nil, testError
 		},
 	}
-	fs := &FileSystem{mockFS}
+	fs := &FileSystem{FileSystem: mockFS}
 
 	file, err := fs.Open("foo")
 
@@ -51,5 +54,65 @@ func TestFileSystem_Open_err(t *testing.T) {
 	assert.Nil(t, file)
 }
 
+func TestFileSystem_Open_traversal(t *testing.T) {
+	mockFS := &mockFileSystem{
+		open: func(name string) (http.File, error) {
+			t.Fatalf("upstream opened %q", name)
+			return nil, nil
+		},
+	}
+	fs := &FileSystem{FileSystem: mockFS}
+
+	for _, name := range []string{"../../etc/passwd", "/static/../../etc/passwd", `..\..\windows\win.ini`} {
+		file, err := fs.Open(name)
+		require.ErrorIs(t, err, os.ErrNotExist, name)
+		assert.Nil(t, file)
+	}
+}
+
+func TestFileSystem_Open_symlinkEscape(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	require.NoError(t, os.Mkdir(root, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret"), []byte("secret"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "public"), []byte("public"), 0o600))
+	if err := os.Symlink(filepath.Join(dir, "secret"), filepath.Join(root, "link")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	fs := FileSystem{FileSystem: http.Dir(root)}
+
+	file, err := fs.Open("link")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	assert.Nil(t, file)
+
+	file, err = fs.Open("public")
+	require.NoError(t, err)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "public", string(content))
+}
+
+func TestFileSystem_Open_directory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "uploads"), 0o700))
+
+	file, err := FileSystem{FileSystem: http.Dir(dir)}.Open("uploads")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	assert.Nil(t, file)
+
+	file, err = FileSystem{FileSystem: http.Dir(dir), AllowListing: true}.Open("uploads")
+	require.NoError(t, err)
+	defer file.Close()
+	info, err := file.Stat()
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	// directories stay available to fs.Glob when listing is allowed
+	matches, err := fs.Glob(FileSystem{FileSystem: http.Dir(dir), AllowListing: true}, "*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"uploads"}, matches)
+}
+
 
 /* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,63 @@
+/* bytes.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Bytes contains ContentType and pre-serialized bytes data, written as is
+// together with an ETag computed from the bytes. Use NewBytes to compute the
+// ETag once when the same Bytes is served repeatedly.
+type Bytes struct {
+	ContentType string
+	Data        []byte
+
+	etag string
+}
+
+// NewBytes returns a Bytes with its ETag computed up front.
+func NewBytes(contentType string, data []byte) Bytes {
+	r := Bytes{ContentType: contentType, Data: data}
+	r.etag = r.ETag()
+	return r
+}
+
+// ETag returns the strong entity tag of the bytes data.
+func (r Bytes) ETag() string {
+	if r.etag != "" {
+		return r.etag
+	}
+	sum := sha256.Sum256(r.Data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// Modified reports whether the full body has to be sent to req, i.e. whether
+// its conditional headers, evaluated against the ETag as by Conditional,
+// neither answer 304 Not Modified nor 412 Precondition Failed.
+func (r Bytes) Modified(req *http.Request) bool {
+	return preconditions(req, r.ETag(), time.Time{}) == 0
+}
+
+// Render (Bytes) writes the ETag header and data with custom ContentType.
+func (r Bytes) Render(w http.ResponseWriter) (err error) {
+	r.WriteContentType(w)
+	_, err = w.Write(r.Data)
+	return
+}
+
+// WriteContentType (Bytes) writes custom ContentType and the ETag header.
+func (r Bytes) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, []string{r.ContentType})
+	w.Header().Set("ETag", r.ETag())
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,41 @@
+/* cbor.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"net/http"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// CBOR contains the given interface object.
+type CBOR struct {
+	Data any
+}
+
+var cborContentType = []string{"application/cbor"}
+
+// Render (CBOR) marshals the given interface object and writes data with custom ContentType.
+func (r CBOR) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	bytes, err := cbor.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(bytes)
+	return err
+}
+
+// WriteContentType (CBOR) writes CBOR ContentType for response.
+func (r CBOR) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, cborContentType)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,142 @@
+/* conditional.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Conditional writes the ETag and Last-Modified validator headers of the
+// representation to w, skipping empty ones, and evaluates the conditional
+// headers of req against them with the precedence of RFC 7232 section 6.
+// It returns http.StatusNotModified or http.StatusPreconditionFailed when the
+// response must be that status without a body, and 0 when the representation
+// should be sent.
+func Conditional(w http.ResponseWriter, req *http.Request, etag string, lastModified time.Time) int {
+	header := w.Header()
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	return preconditions(req, etag, lastModified)
+}
+
+// preconditions evaluates the conditional headers of req against etag and
+// lastModified like Conditional, without writing any header.
+func preconditions(req *http.Request, etag string, lastModified time.Time) int {
+	if req == nil {
+		return 0
+	}
+	lastModified = lastModified.Truncate(time.Second)
+
+	// 1. If-Match, else 2. If-Unmodified-Since
+	if ifMatch := req.Header.Get("If-Match"); ifMatch != "" {
+		if !etagMatchesStrong(ifMatch, etag) {
+			return http.StatusPreconditionFailed
+		}
+	} else if since, ok := headerTime(req, "If-Unmodified-Since"); ok && !lastModified.IsZero() {
+		if lastModified.After(since) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	safe := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	// 3. If-None-Match, else 4. If-Modified-Since
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if etagMatches(ifNoneMatch, etag) {
+			if safe {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	} else if since, ok := headerTime(req, "If-Modified-Since"); ok && safe && !lastModified.IsZero() {
+		if !lastModified.After(since) {
+			return http.StatusNotModified
+		}
+	}
+	return 0
+}
+
+// ConditionalRender wraps Body with conditional request handling, writing the
+// status returned by Conditional without a body instead of rendering Body.
+type ConditionalRender struct {
+	Body         Render
+	Request      *http.Request
+	ETag         string
+	LastModified time.Time
+}
+
+// Render (ConditionalRender) renders Body unless the request conditions say otherwise.
+func (r ConditionalRender) Render(w http.ResponseWriter) error {
+	if status := Conditional(w, r.Request, r.ETag, r.LastModified); status != 0 {
+		w.WriteHeader(status)
+		return nil
+	}
+	return r.Body.Render(w)
+}
+
+// WriteContentType (ConditionalRender) writes the Content-Type of Body.
+func (r ConditionalRender) WriteContentType(w http.ResponseWriter) {
+	r.Body.WriteContentType(w)
+}
+
+// etagMatches reports whether the If-None-Match header matches etag, using
+// the weak comparison of RFC 9110.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	if etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate != "" && candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagMatchesStrong reports whether the If-Match header matches etag, using
+// the strong comparison of RFC 9110: weak tags never match.
+func etagMatchesStrong(ifMatch, etag string) bool {
+	if strings.TrimSpace(ifMatch) == "*" {
+		return true
+	}
+	if etag == "" || strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for candidate := range strings.SplitSeq(ifMatch, ",") {
+		if strings.TrimSpace(candidate) == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// headerTime parses the HTTP date in the header key of req.
+func headerTime(req *http.Request, key string) (time.Time, bool) {
+	value := req.Header.Get(key)
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := http.ParseTime(value)
+	return t, err == nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,161 @@
+/* csv.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var csvContentType = []string{"text/csv; charset=utf-8"}
+
+// errCSVData is returned when CSV.Data is neither a [][]string nor a slice of structs.
+var errCSVData = errors.New("csv render requires a [][]string or a slice of structs")
+
+// CSV writes Data as comma separated values through encoding/csv, quoting
+// fields that contain the delimiter, quotes or newlines. Data is either a
+// [][]string, written as is, or a slice of structs or struct pointers, one row
+// per element with columns in field order named by their `csv` tag or else
+// their field name. A field tagged `csv:"-"` is left out.
+type CSV struct {
+	Data any
+	// Comma is the field delimiter, ',' when zero.
+	Comma rune
+	// OmitHeader leaves out the header row of column names written before
+	// the rows of a slice of structs.
+	OmitHeader bool
+	// Filename sends the content as an attachment with the given file name.
+	Filename string
+}
+
+// csvColumn is an exported struct field written as a CSV column.
+type csvColumn struct {
+	name  string
+	index int
+}
+
+// Render (CSV) writes Data as CSV rows with custom ContentType.
+func (r CSV) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	if r.Filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": r.Filename}))
+	}
+
+	cw := csv.NewWriter(w)
+	if r.Comma != 0 {
+		cw.Comma = r.Comma
+	}
+	if err := r.writeRows(cw); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (r CSV) writeRows(cw *csv.Writer) error {
+	if records, ok := r.Data.([][]string); ok {
+		for _, record := range records {
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rows := reflect.ValueOf(r.Data)
+	if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
+		return errCSVData
+	}
+	elemType := rows.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return errCSVData
+	}
+
+	columns := csvColumns(elemType)
+	record := make([]string, len(columns))
+	if !r.OmitHeader {
+		for i, column := range columns {
+			record[i] = column.name
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	for i := range rows.Len() {
+		row := rows.Index(i)
+		if row.Kind() == reflect.Pointer {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+		for j, column := range columns {
+			record[j] = csvField(row.Field(column.index))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvColumns lists the exported fields of struct type t in field order.
+func csvColumns(t reflect.Type) []csvColumn {
+	columns := make([]csvColumn, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("csv"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, csvColumn{name: name, index: i})
+	}
+	return columns
+}
+
+// csvField formats a field value, through encoding.TextMarshaler when the
+// value implements it. Nil pointers and interfaces are written empty.
+func csvField(v reflect.Value) string {
+	for {
+		indirect := v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface
+		if indirect && v.IsNil() {
+			return ""
+		}
+		if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+			if text, err := m.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+		if !indirect {
+			return fmt.Sprint(v.Interface())
+		}
+		v = v.Elem()
+	}
+}
+
+// WriteContentType (CSV) writes CSV ContentType.
+func (r CSV) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, csvContentType)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,120 @@
+/* envelope.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// EnvelopeFields contains the field names used by Envelope.
+// Empty names fall back to DefaultEnvelopeFields.
+type EnvelopeFields struct {
+	Success string
+	Data    string
+	Error   string
+	TraceID string
+}
+
+// DefaultEnvelopeFields are the field names of a `{"success", "data", "error"}` envelope.
+var DefaultEnvelopeFields = EnvelopeFields{
+	Success: "success",
+	Data:    "data",
+	Error:   "error",
+	TraceID: "trace_id",
+}
+
+type envelopeMember struct {
+	name  string
+	value any
+}
+
+// Envelope contains the given interface object wrapped in a standard success envelope.
+type Envelope struct {
+	Fields  EnvelopeFields
+	Success bool
+	Data    any
+	Error   any
+	TraceID string
+}
+
+// Render (Envelope) writes the envelope as JSON with custom ContentType.
+// Fields are written in success, data, error order, followed by the trace id
+// when set. An error value is rendered as its message.
+func (r Envelope) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	fields := r.Fields.withDefaults()
+	errValue := r.Error
+	if err, ok := errValue.(error); ok {
+		errValue = err.Error()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	members := []envelopeMember{
+		{fields.Success, r.Success},
+		{fields.Data, r.Data},
+		{fields.Error, errValue},
+	}
+	if r.TraceID != "" {
+		members = append(members, envelopeMember{fields.TraceID, r.TraceID})
+	}
+	for i, field := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSONField(&buf, field.name, field.value); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteContentType (Envelope) writes JSON ContentType.
+func (r Envelope) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonContentType)
+}
+
+func (f EnvelopeFields) withDefaults() EnvelopeFields {
+	if f.Success == "" {
+		f.Success = DefaultEnvelopeFields.Success
+	}
+	if f.Data == "" {
+		f.Data = DefaultEnvelopeFields.Data
+	}
+	if f.Error == "" {
+		f.Error = DefaultEnvelopeFields.Error
+	}
+	if f.TraceID == "" {
+		f.TraceID = DefaultEnvelopeFields.TraceID
+	}
+	return f
+}
+
+func writeJSONField(buf *bytes.Buffer, name string, value any) error {
+	key, err := json.API.Marshal(name)
+	if err != nil {
+		return err
+	}
+	val, err := json.API.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(key)
+	buf.WriteByte(':')
+	buf.Write(val)
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,79 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"io"
+	"net/http"
+)
+
+// FlushEvery controls how often a streaming renderer flushes the response.
+// A flush happens once either threshold is reached. The zero value flushes
+// after every record.
+type FlushEvery struct {
+	// Bytes flushes once this many bytes were written since the last flush.
+	Bytes int
+	// Records flushes once this many records were written since the last flush.
+	Records int
+}
+
+// FlushWriter wraps a writer and flushes it at FlushEvery boundaries.
+type FlushWriter struct {
+	w       io.Writer
+	every   FlushEvery
+	bytes   int
+	records int
+}
+
+// NewFlushWriter returns a FlushWriter that flushes w according to every.
+// Flushing is a no-op when w does not implement http.Flusher.
+func NewFlushWriter(w io.Writer, every FlushEvery) *FlushWriter {
+	return &FlushWriter{w: w, every: every}
+}
+
+// Write writes p without flushing, flushes only happen at record boundaries.
+func (f *FlushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.bytes += n
+	return n, err
+}
+
+// EndRecord marks the end of a record and flushes when a threshold is reached.
+func (f *FlushWriter) EndRecord() {
+	f.records++
+	switch {
+	case f.every == (FlushEvery{}),
+		f.every.Records > 0 && f.records >= f.every.Records,
+		f.every.Bytes > 0 && f.bytes >= f.every.Bytes:
+		f.Flush()
+	}
+}
+
+// Flush flushes anything written since the last flush.
+func (f *FlushWriter) Flush() {
+	if f.bytes == 0 && f.records == 0 {
+		return
+	}
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	f.bytes, f.records = 0, 0
+}
+
+// chunkWriter ends a record after every write.
+type chunkWriter struct {
+	*FlushWriter
+}
+
+func (c chunkWriter) Write(p []byte) (int, error) {
+	n, err := c.FlushWriter.Write(p)
+	c.EndRecord()
+	return n, err
+}
+
+
+/* ucm:n1ch52f3a7c0 */
\ No newline at end of file
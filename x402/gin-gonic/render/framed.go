@@ -0,0 +1,87 @@
+/* framed.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+)
+
+// DefaultFramePrefixSize is the length-prefix size used when FramedBinary.PrefixSize is zero.
+const DefaultFramePrefixSize = 4
+
+var octetStreamContentType = []string{"application/octet-stream"}
+
+// FramedBinary writes each message received from Messages as a binary frame:
+// a big-endian length prefix followed by the message bytes. The response is
+// flushed after every frame by default and rendering ends once Messages is
+// closed.
+type FramedBinary struct {
+	// ContentType defaults to "application/octet-stream".
+	ContentType string
+	// PrefixSize is the length-prefix size in bytes, 2, 4 or 8.
+	PrefixSize int
+	Messages   <-chan []byte
+	// FlushEvery controls how often the response is flushed. The zero value
+	// flushes after every frame.
+	FlushEvery FlushEvery
+}
+
+// Render (FramedBinary) writes length-prefixed frames with custom ContentType.
+func (r FramedBinary) Render(w http.ResponseWriter) error {
+	size := r.PrefixSize
+	if size == 0 {
+		size = DefaultFramePrefixSize
+	}
+	if size != 2 && size != 4 && size != 8 {
+		return fmt.Errorf("invalid frame prefix size %d", size)
+	}
+
+	r.WriteContentType(w)
+	fw := NewFlushWriter(w, r.FlushEvery)
+	prefix := make([]byte, size)
+	for msg := range r.Messages {
+		length := uint64(len(msg))
+		switch size {
+		case 2:
+			if length > 0xFFFF {
+				return fmt.Errorf("frame of %d bytes exceeds 2-byte length prefix", length)
+			}
+			binary.BigEndian.PutUint16(prefix, uint16(length))
+		case 4:
+			if length > 0xFFFFFFFF {
+				return fmt.Errorf("frame of %d bytes exceeds 4-byte length prefix", length)
+			}
+			binary.BigEndian.PutUint32(prefix, uint32(length))
+		case 8:
+			binary.BigEndian.PutUint64(prefix, length)
+		}
+
+		if _, err := fw.Write(prefix); err != nil {
+			return err
+		}
+		if _, err := fw.Write(msg); err != nil {
+			return err
+		}
+		fw.EndRecord()
+	}
+	fw.Flush()
+	return nil
+}
+
+// WriteContentType (FramedBinary) writes custom ContentType.
+func (r FramedBinary) WriteContentType(w http.ResponseWriter) {
+	if r.ContentType == "" {
+		writeContentType(w, octetStreamContentType)
+		return
+	}
+	writeContentType(w, []string{r.ContentType})
+}
+
+
+/* ucm:n1ch4f3e2b17 */
\ No newline at end of file
@@ -83,7 +83,7 @@ func (r HTMLDebug) loadTemplate() *template.Template {
 	}
 	if r.FileSystem != nil && len(r.Patterns) > 0 {
 		return template.Must(template.New("").Delims(r.Delims.Left, r.Delims.Right).Funcs(r.FuncMap).ParseFS(
-			fs.FileSystem{FileSystem: r.FileSystem}, r.Patterns...))
+			fs.FileSystem{FileSystem: r.FileSystem, AllowListing: true}, r.Patterns...))
 	}
 	panic("the HTML debug render was created without files or glob pattern or file system with patterns")
 }
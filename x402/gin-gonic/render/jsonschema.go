@@ -0,0 +1,154 @@
+/* jsonschema.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// jsonSchemaDialect is the JSON Schema draft the generated schemas conform to.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var jsonSchemaContentType = []string{"application/schema+json"}
+
+// JSONSchema renders a JSON Schema describing the type of Data, as encoded by
+// encoding/json. Fields tagged `binding:"required"` are required, and the
+// email, url, uri and uuid validations map to the matching formats.
+type JSONSchema struct {
+	Data any
+}
+
+// Render (JSONSchema) writes the schema of Data with custom ContentType.
+func (r JSONSchema) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	schema := schemaOf(reflect.TypeOf(r.Data), map[reflect.Type]bool{})
+	schema["$schema"] = jsonSchemaDialect
+	return json.API.NewEncoder(w).Encode(schema)
+}
+
+// WriteContentType (JSONSchema) writes JSONSchema ContentType.
+func (r JSONSchema) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonSchemaContentType)
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	schemaBindingFormat = map[string]string{"email": "email", "url": "uri", "uri": "uri", "uuid": "uuid"}
+)
+
+// schemaOf returns the schema of t. Types in visiting are being described
+// already and are left unconstrained to break recursion.
+func schemaOf(t reflect.Type, visiting map[reflect.Type]bool) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]any{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		schema := map[string]any{"type": "array", "items": schemaOf(t.Elem(), visiting)}
+		if t.Kind() == reflect.Array {
+			schema["minItems"], schema["maxItems"] = t.Len(), t.Len()
+		}
+		return schema
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return map[string]any{"type": "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := map[string]any{}
+		required := []string{}
+		structSchema(t, visiting, properties, &required)
+		schema := map[string]any{"type": "object", "properties": properties}
+		if t.Name() != "" {
+			schema["title"] = t.Name()
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema adds the properties of struct t to properties, flattening
+// embedded structs the way encoding/json does.
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				structSchema(ft, visiting, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaOf(field.Type, visiting)
+		for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+			rule, _, _ = strings.Cut(rule, "=")
+			if rule == "required" {
+				*required = append(*required, name)
+			} else if format, ok := schemaBindingFormat[rule]; ok {
+				property["format"] = format
+			}
+		}
+		properties[name] = property
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
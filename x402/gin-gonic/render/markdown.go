@@ -0,0 +1,66 @@
+/**
+ * @file markdown.go
+ * @author nirholas
+ * @copyright (c) 2026 nich
+ * @license MIT
+ * @repository universal-crypto-mcp
+ * @version 0.14.9.3
+ * @checksum 0x4E494348
+ */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+)
+
+// Markdown contains the given Markdown source.
+type Markdown struct {
+	Source string
+	// Raw writes the source as text/markdown instead of rendering it to HTML.
+	Raw bool
+}
+
+var (
+	markdownContentType = []string{"text/markdown; charset=utf-8"}
+
+	// markdownPolicy strips scripts, event handlers and other unsafe markup
+	// from the rendered HTML, since the Markdown source may be user supplied.
+	markdownPolicy = bluemonday.UGCPolicy()
+)
+
+// Render (Markdown) converts the Markdown source to sanitized HTML and writes it with custom ContentType.
+func (r Markdown) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	if r.Raw {
+		_, err := w.Write([]byte(r.Source))
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(r.Source), &buf); err != nil {
+		return err
+	}
+	_, err := w.Write(markdownPolicy.SanitizeBytes(buf.Bytes()))
+	return err
+}
+
+// WriteContentType (Markdown) writes HTML ContentType, or Markdown ContentType when Raw is set.
+func (r Markdown) WriteContentType(w http.ResponseWriter) {
+	if r.Raw {
+		writeContentType(w, markdownContentType)
+		return
+	}
+	writeContentType(w, htmlContentType)
+}
+
+
+/* universal-crypto-mcp © universal-crypto-mcp */
\ No newline at end of file
@@ -14,7 +14,12 @@
 package render
 
 import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/ugorji/go/codec"
 )
@@ -44,10 +49,90 @@ func (r MsgPack) Render(w http.ResponseWriter) error {
 }
 
 // WriteMsgPack writes MsgPack ContentType and encodes the given interface object.
-func WriteMsgPack(w http.ResponseWriter, obj any) error {
+// Nil data and values holding channels or functions are rejected with an error,
+// nothing is written unless the whole object could be encoded.
+func WriteMsgPack(w http.ResponseWriter, obj any) (err error) {
 	writeContentType(w, msgpackContentType)
+	if obj == nil {
+		return errors.New("msgpack: cannot encode nil data")
+	}
+	if err := checkMsgPackValue(reflect.ValueOf(obj), map[uintptr]bool{}); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("msgpack: cannot encode %T: %v", obj, r)
+		}
+	}()
 	var mh codec.MsgpackHandle
-	return codec.NewEncoder(w, &mh).Encode(obj)
+	if err := codec.NewEncoder(&buf, &mh).Encode(obj); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+var (
+	msgpackSelferType          = reflect.TypeFor[codec.Selfer]()
+	msgpackBinaryMarshalerType = reflect.TypeFor[encoding.BinaryMarshaler]()
+)
+
+// checkMsgPackValue returns an error if v holds a value msgpack has no encoding
+// for, which the encoder would otherwise silently write as nil or an empty array.
+// Pointers in seen were checked already.
+func checkMsgPackValue(v reflect.Value, seen map[uintptr]bool) error {
+	if !v.IsValid() {
+		return nil
+	}
+	if t := v.Type(); t.Implements(msgpackSelferType) || t.Implements(msgpackBinaryMarshalerType) {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return nil
+		}
+		seen[v.Pointer()] = true
+		return checkMsgPackValue(v.Elem(), seen)
+	case reflect.Interface:
+		return checkMsgPackValue(v.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := range v.Len() {
+			if err := checkMsgPackValue(v.Index(i), seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkMsgPackValue(iter.Key(), seen); err != nil {
+				return err
+			}
+			if err := checkMsgPackValue(iter.Value(), seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("codec") == "-" || field.Tag.Get("json") == "-" {
+				continue
+			}
+			if err := checkMsgPackValue(v.Field(i), seen); err != nil {
+				return fmt.Errorf("%w in field %s", err, field.Name)
+			}
+		}
+	}
+	return nil
 }
 
 
@@ -0,0 +1,115 @@
+/* ndjson.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+var ndjsonContentType = []string{"application/x-ndjson"}
+
+// NDJSONRecordError reports a record that could not be marshaled. The record
+// is left out of the stream, which stays valid NDJSON.
+type NDJSONRecordError struct {
+	// Index is the 0-based position of the record in the input.
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *NDJSONRecordError) Error() string {
+	return fmt.Sprintf("ndjson record %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the marshal error.
+func (e *NDJSONRecordError) Unwrap() error {
+	return e.Err
+}
+
+// NDJSON writes every record received from Records, or else yielded by Seq,
+// as one JSON document per line, flushing after each record by default.
+// Records that fail to marshal are skipped and reported as NDJSONRecordError
+// once rendering ends.
+type NDJSON struct {
+	Records <-chan any
+	Seq     iter.Seq[any]
+	// Context stops rendering when done, usually the request context so that
+	// rendering stops once the client disconnected. Producers sending on
+	// Records should watch it too.
+	Context context.Context
+	// FlushEvery controls how often the response is flushed. The zero value
+	// flushes after every record.
+	FlushEvery FlushEvery
+}
+
+// Render (NDJSON) writes records with custom ContentType.
+func (r NDJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	ctx := r.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	fw := NewFlushWriter(w, r.FlushEvery)
+	defer fw.Flush()
+
+	var errs []error
+	index := 0
+	write := func(record any) error {
+		defer func() { index++ }()
+		line, err := json.API.Marshal(record)
+		if err != nil {
+			errs = append(errs, &NDJSONRecordError{Index: index, Err: err})
+			return nil
+		}
+		if _, err := fw.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		fw.EndRecord()
+		return nil
+	}
+
+	switch {
+	case r.Records != nil:
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case record, ok := <-r.Records:
+				if !ok {
+					return errors.Join(errs...)
+				}
+				if err := write(record); err != nil {
+					return err
+				}
+			}
+		}
+	case r.Seq != nil:
+		for record := range r.Seq {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WriteContentType (NDJSON) writes NDJSON ContentType.
+func (r NDJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, ndjsonContentType)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
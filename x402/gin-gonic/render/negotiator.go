@@ -0,0 +1,153 @@
+/* negotiator.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RenderFunc builds the renderer of data for a negotiated media type.
+type RenderFunc func(data any) Render
+
+// Negotiator picks the renderer of a response from the Accept request header
+// among registered media types. The media type accepted with the highest
+// q-value wins; ties go to the most specific Accept match, then to the type
+// registered first. Registering is not safe while negotiating.
+type Negotiator struct {
+	// Fallback is the media type rendered when nothing registered is
+	// acceptable, application/json for NewNegotiator.
+	Fallback string
+
+	types     []string
+	renderers map[string]RenderFunc
+}
+
+// DefaultNegotiator is used by Context.NegotiateRender.
+var DefaultNegotiator = NewNegotiator()
+
+// NewNegotiator returns a Negotiator offering JSON, XML, YAML, TOML, CBOR and
+// ProtoBuf, in that order, falling back to JSON.
+func NewNegotiator() *Negotiator {
+	n := &Negotiator{Fallback: "application/json"}
+	n.Register("application/json", func(data any) Render { return JSON{Data: data} })
+	n.Register("application/xml", func(data any) Render { return XML{Data: data} })
+	n.Register("text/xml", func(data any) Render { return XML{Data: data} })
+	n.Register("application/yaml", func(data any) Render { return YAML{Data: data} })
+	n.Register("application/x-yaml", func(data any) Render { return YAML{Data: data} })
+	n.Register("application/toml", func(data any) Render { return TOML{Data: data} })
+	n.Register("application/cbor", func(data any) Render { return CBOR{Data: data} })
+	n.Register("application/x-protobuf", func(data any) Render { return ProtoBuf{Data: data} })
+	return n
+}
+
+// Register offers mediaType, rendered by fn. Registering a media type again
+// replaces its renderer and keeps its precedence.
+func (n *Negotiator) Register(mediaType string, fn RenderFunc) *Negotiator {
+	mediaType = strings.ToLower(mediaType)
+	if n.renderers == nil {
+		n.renderers = make(map[string]RenderFunc)
+	}
+	if _, ok := n.renderers[mediaType]; !ok {
+		n.types = append(n.types, mediaType)
+	}
+	n.renderers[mediaType] = fn
+	return n
+}
+
+// Negotiate returns the registered media type best matching the Accept
+// header accept and its RenderFunc. ok is false when nothing registered is
+// acceptable; an empty header accepts anything.
+func (n *Negotiator) Negotiate(accept string) (mediaType string, fn RenderFunc, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		if len(n.types) == 0 {
+			return "", nil, false
+		}
+		return n.types[0], n.renderers[n.types[0]], true
+	}
+
+	ranges := parseMediaRanges(accept)
+	bestQ, bestSpecificity := 0.0, -1
+	for _, offer := range n.types {
+		q, specificity := matchMediaRange(ranges, offer)
+		if q > bestQ || (q == bestQ && q > 0 && specificity > bestSpecificity) {
+			mediaType, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	if mediaType == "" {
+		return "", nil, false
+	}
+	return mediaType, n.renderers[mediaType], true
+}
+
+// Render returns the renderer of data for the Accept header accept, using
+// the Fallback media type when nothing registered is acceptable.
+func (n *Negotiator) Render(accept string, data any) Render {
+	if _, fn, ok := n.Negotiate(accept); ok {
+		return fn(data)
+	}
+	if fn, ok := n.renderers[strings.ToLower(n.Fallback)]; ok {
+		return fn(data)
+	}
+	return JSON{Data: data}
+}
+
+// mediaRange is an entry of an Accept header.
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+func parseMediaRanges(accept string) []mediaRange {
+	var ranges []mediaRange
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+		if !ok {
+			continue
+		}
+		r := mediaRange{typ: typ, subtype: subtype, q: 1}
+		for param := range strings.SplitSeq(params, ";") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if q, err := strconv.ParseFloat(v, 64); err == nil {
+					r.q = q
+				}
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// matchMediaRange returns the q-value of the most specific media range
+// matching mediaType, with its specificity: 2 for type/subtype, 1 for type/*
+// and 0 for */*. A mediaType matching nothing has a q-value of 0.
+func matchMediaRange(ranges []mediaRange, mediaType string) (q float64, specificity int) {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	specificity = -1
+	for _, r := range ranges {
+		s := -1
+		switch {
+		case r.typ == typ && r.subtype == subtype:
+			s = 2
+		case r.typ == typ && r.subtype == "*":
+			s = 1
+		case r.typ == "*" && r.subtype == "*":
+			s = 0
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	if specificity < 0 {
+		return 0, specificity
+	}
+	return q, specificity
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
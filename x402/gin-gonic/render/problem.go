@@ -0,0 +1,65 @@
+/* problem.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// ProblemDetails is an RFC 9457 problem details object. An empty Type means
+// "about:blank", in which case Title should be the HTTP status text.
+type ProblemDetails struct {
+	XMLName  xml.Name `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+	Type     string   `json:"type,omitempty" xml:"type,omitempty"`
+	Title    string   `json:"title,omitempty" xml:"title,omitempty"`
+	Status   int      `json:"status,omitempty" xml:"status,omitempty"`
+	Detail   string   `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance string   `json:"instance,omitempty" xml:"instance,omitempty"`
+}
+
+// Problem renders ProblemDetails as JSON.
+type Problem struct {
+	Data ProblemDetails
+}
+
+// ProblemXML renders ProblemDetails as XML.
+type ProblemXML struct {
+	Data ProblemDetails
+}
+
+var (
+	problemJSONContentType = []string{"application/problem+json"}
+	problemXMLContentType  = []string{"application/problem+xml"}
+)
+
+// Render (Problem) writes data with the "application/problem+json" ContentType.
+func (r Problem) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.API.NewEncoder(w).Encode(r.Data)
+}
+
+// WriteContentType (Problem) writes Problem ContentType.
+func (r Problem) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, problemJSONContentType)
+}
+
+// Render (ProblemXML) writes data with the "application/problem+xml" ContentType.
+func (r ProblemXML) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return xml.NewEncoder(w).Encode(r.Data)
+}
+
+// WriteContentType (ProblemXML) writes ProblemXML ContentType.
+func (r ProblemXML) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, problemXMLContentType)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,54 @@
+/**
+ * @file protostream.go
+ * @author @nichxbt
+ * @copyright (c) 2026 nirholas/universal-crypto-mcp
+ * @license MIT
+ * @repository universal-crypto-mcp
+ */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+var protoStreamContentType = []string{"application/x-protobuf-stream"}
+
+// ProtoStream writes each message received from Messages length-delimited:
+// a varint length prefix followed by the marshaled message, as read back by
+// protodelim.UnmarshalFrom. Rendering ends once Messages is closed.
+type ProtoStream struct {
+	Messages <-chan proto.Message
+	// FlushEvery controls how often the response is flushed. The zero value
+	// flushes after every message.
+	FlushEvery FlushEvery
+}
+
+// Render (ProtoStream) writes length-delimited messages with custom ContentType.
+func (r ProtoStream) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	fw := NewFlushWriter(w, r.FlushEvery)
+	for msg := range r.Messages {
+		if _, err := protodelim.MarshalTo(fw, msg); err != nil {
+			return err
+		}
+		fw.EndRecord()
+	}
+	fw.Flush()
+	return nil
+}
+
+// WriteContentType (ProtoStream) writes ProtoStream ContentType.
+func (r ProtoStream) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, protoStreamContentType)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,109 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"io"
+	"net/http"
+	"net/textproto"
+	"strconv"
+)
+
+// hopHeaders are connection specific and never forwarded by Proxy.
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Proxy streams an upstream response to the client: its status, a filtered
+// set of its headers and its body, by default flushed as it is read.
+// Hop-by-hop headers are always dropped. The upstream body is closed once
+// rendered.
+type Proxy struct {
+	Upstream *http.Response
+	// AllowHeaders, when not empty, lists the only upstream headers forwarded.
+	AllowHeaders []string
+	// DenyHeaders lists upstream headers that are never forwarded.
+	DenyHeaders []string
+	// FlushEvery controls how often the body is flushed, each read from the
+	// upstream body being a record. The zero value flushes after every read.
+	FlushEvery FlushEvery
+}
+
+// Render (Proxy) copies the upstream status, headers and body.
+func (r Proxy) Render(w http.ResponseWriter) error {
+	defer r.Upstream.Body.Close()
+
+	r.writeHeaders(w)
+	w.WriteHeader(r.Upstream.StatusCode)
+
+	fw := NewFlushWriter(w, r.FlushEvery)
+	_, err := io.Copy(chunkWriter{fw}, r.Upstream.Body)
+	fw.Flush()
+	return err
+}
+
+// WriteContentType (Proxy) writes the upstream ContentType.
+func (r Proxy) WriteContentType(w http.ResponseWriter) {
+	if contentType := r.Upstream.Header.Values("Content-Type"); len(contentType) > 0 && r.forwards("Content-Type") {
+		writeContentType(w, contentType)
+	}
+}
+
+func (r Proxy) writeHeaders(w http.ResponseWriter) {
+	header := w.Header()
+	for k, vv := range r.Upstream.Header {
+		if !r.forwards(k) {
+			continue
+		}
+		header.Del(k)
+		for _, v := range vv {
+			header.Add(k, v)
+		}
+	}
+
+	// The body is streamed as is, so a known length is kept and an unknown
+	// one leaves the framing to the server
+	header.Del("Content-Length")
+	if r.Upstream.ContentLength >= 0 && r.forwards("Content-Length") {
+		header.Set("Content-Length", strconv.FormatInt(r.Upstream.ContentLength, 10))
+	}
+}
+
+// forwards reports whether the upstream header key is passed to the client.
+func (r Proxy) forwards(key string) bool {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	for _, h := range hopHeaders {
+		if key == h {
+			return false
+		}
+	}
+	for _, h := range r.DenyHeaders {
+		if key == textproto.CanonicalMIMEHeaderKey(h) {
+			return false
+		}
+	}
+	if len(r.AllowHeaders) == 0 {
+		return true
+	}
+	for _, h := range r.AllowHeaders {
+		if key == textproto.CanonicalMIMEHeaderKey(h) {
+			return true
+		}
+	}
+	return false
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
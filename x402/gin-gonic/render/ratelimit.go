@@ -0,0 +1,76 @@
+/* ratelimit.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitInfo describes the quota of a client, sent as the RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers of the IETF RateLimit header
+// fields draft. A zero Limit sends none of them.
+type RateLimitInfo struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time until the window resets, sent in whole seconds.
+	Reset time.Duration
+}
+
+// RateLimited renders a 429 answer: a Retry-After header, the RateLimit
+// headers of Info and a JSON error body.
+type RateLimited struct {
+	// RetryAfter is the time the client should wait, sent in whole seconds.
+	RetryAfter time.Duration
+	Info       RateLimitInfo
+	// Data is the JSON body, `{"error":"rate limit exceeded","retryAfter":<seconds>}`
+	// when nil.
+	Data any
+}
+
+// Render (RateLimited) writes the rate-limit headers and marshals Data as JSON.
+func (r RateLimited) Render(w http.ResponseWriter) error {
+	r.writeHeaders(w)
+	data := r.Data
+	if data == nil {
+		data = map[string]any{
+			"error":      "rate limit exceeded",
+			"retryAfter": deltaSeconds(r.RetryAfter),
+		}
+	}
+	return WriteJSON(w, data)
+}
+
+// WriteContentType (RateLimited) writes the rate-limit headers and JSON ContentType.
+func (r RateLimited) WriteContentType(w http.ResponseWriter) {
+	r.writeHeaders(w)
+	writeContentType(w, jsonContentType)
+}
+
+func (r RateLimited) writeHeaders(w http.ResponseWriter) {
+	header := w.Header()
+	header.Set("Retry-After", strconv.FormatInt(deltaSeconds(r.RetryAfter), 10))
+	if r.Info.Limit > 0 {
+		header.Set("RateLimit-Limit", strconv.Itoa(r.Info.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(max(r.Info.Remaining, 0)))
+		header.Set("RateLimit-Reset", strconv.FormatInt(deltaSeconds(r.Info.Reset), 10))
+	}
+}
+
+// deltaSeconds rounds d up to whole seconds, so clients never retry early.
+func deltaSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64((d + time.Second - 1) / time.Second)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
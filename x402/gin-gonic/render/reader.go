@@ -15,9 +15,19 @@
 package render
 
 import (
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"hash/crc32"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Reader contains the IO reader and its length, and custom ContentType and other headers.
@@ -26,12 +36,85 @@ type Reader struct {
 	ContentLength int64
 	Reader        io.Reader
 	Headers       map[string]string
+	// FlushEvery flushes the response while copying, treating each chunk
+	// read from Reader as a record. The zero value never flushes.
+	FlushEvery FlushEvery
+	// Range is the Range request header. It is only honored when Reader is
+	// an io.ReadSeeker and ContentLength is known; a single range is served
+	// as is, multiple ranges as multipart/byteranges.
+	Range string
+	// PartContentType resolves the Content-Type of each part of a
+	// multipart/byteranges response from the part index and its inclusive
+	// byte range. An empty result falls back to ContentType.
+	PartContentType func(index int, start, end int64) string
+	// ETag is sent as the ETag header, e.g. `"v1"` or `W/"v1"`.
+	ETag string
+	// LastModified is sent as the Last-Modified header unless zero.
+	LastModified time.Time
+	// Request is evaluated against ETag and LastModified by Conditional; a 304
+	// Not Modified or 412 Precondition Failed is written without a body.
+	Request *http.Request
+	// WeakETag computes a weak ETag from ContentLength and a CRC-32 of the
+	// first bytes of content when ETag is empty. It requires Reader to be an
+	// io.ReadSeeker and ContentLength to be known.
+	WeakETag bool
+	// AcceptEncoding is the Accept-Encoding request header. When it accepts
+	// gzip and ContentType is compressible, the full content is gzipped on the
+	// fly and sent without a Content-Length.
+	AcceptEncoding string
+	// DisableCompression serves the content as is regardless of AcceptEncoding.
+	DisableCompression bool
+}
+
+// weakETagSampleSize is the number of leading bytes hashed into a weak ETag.
+const weakETagSampleSize = 64 << 10
+
+// errUnsatisfiableRange is returned when none of the requested ranges overlap the content.
+var errUnsatisfiableRange = errors.New("range not satisfiable")
+
+// httpRange is a byte range of the content, start inclusive and length bytes long.
+type httpRange struct {
+	start, length int64
+}
+
+func (ra httpRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", ra.start, ra.start+ra.length-1, size)
 }
 
 // Render (Reader) writes data with custom ContentType and headers.
 func (r Reader) Render(w http.ResponseWriter) (err error) {
+	etag, err := r.etag()
+	if err != nil {
+		return err
+	}
+	if status := Conditional(w, r.Request, etag, r.LastModified); status != 0 {
+		r.writeHeaders(w)
+		w.WriteHeader(status)
+		return nil
+	}
+
+	if seeker, ok := r.Reader.(io.ReadSeeker); ok && r.Range != "" && r.ContentLength >= 0 {
+		ranges, err := parseRange(r.Range, r.ContentLength)
+		switch {
+		case errors.Is(err, errUnsatisfiableRange):
+			r.writeHeaders(w)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", r.ContentLength))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return nil
+		case err == nil && !rangesServable(ranges, r.ContentLength):
+			// Too many or overlapping ranges could amplify a small request into
+			// a huge response, the full content is served instead
+		case err == nil && len(ranges) == 1:
+			return r.renderRange(w, seeker, ranges[0])
+		case err == nil && len(ranges) > 1:
+			return r.renderMultiRange(w, seeker, ranges)
+		}
+		// A malformed Range header is ignored and the full content is served
+	}
+
 	r.WriteContentType(w)
-	if r.ContentLength >= 0 {
+	compress := r.compress(w)
+	if r.ContentLength >= 0 && !compress {
 		if r.Headers == nil {
 // TODO(universal-crypto-mcp): optimize this section
 			r.Headers = map[string]string{}
@@ -39,10 +122,263 @@ func (r Reader) Render(w http.ResponseWriter) (err error) {
 		r.Headers["Content-Length"] = strconv.FormatInt(r.ContentLength, 10)
 	}
 	r.writeHeaders(w)
-	_, err = io.Copy(w, r.Reader)
+
+	var dst http.ResponseWriter = w
+	if compress {
+		header := w.Header()
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		gz := gzip.NewWriter(w)
+		defer func() {
+			if closeErr := gz.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		dst = gzipResponseWriter{ResponseWriter: w, gz: gz}
+	}
+	if r.FlushEvery == (FlushEvery{}) {
+		_, err = io.Copy(dst, r.Reader)
+		return
+	}
+	fw := NewFlushWriter(dst, r.FlushEvery)
+	_, err = io.Copy(chunkWriter{fw}, r.Reader)
+	fw.Flush()
 	return
 }
 
+// compress reports whether the full content is gzipped, adding
+// Vary: Accept-Encoding whenever the response depends on it.
+func (r Reader) compress(w http.ResponseWriter) bool {
+	if r.DisableCompression || !isCompressible(r.ContentType) {
+		return false
+	}
+	if w.Header().Get("Content-Encoding") != "" || r.Headers["Content-Encoding"] != "" {
+		return false
+	}
+	AddVary(w.Header(), "Accept-Encoding")
+	return acceptsGzip(r.AcceptEncoding)
+}
+
+// gzipResponseWriter writes through a gzip.Writer, flushing the compressed
+// stream to the underlying response on Flush.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz *gzip.Writer
+}
+
+func (g gzipResponseWriter) Write(p []byte) (int, error) {
+	return g.gz.Write(p)
+}
+
+func (g gzipResponseWriter) Flush() {
+	if g.gz.Flush() != nil {
+		return
+	}
+	if f, ok := g.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// compressibleTypes lists the non-text media types worth compressing.
+var compressibleTypes = map[string]bool{
+	"application/json":                  true,
+	"application/javascript":            true,
+	"application/x-javascript":          true,
+	"application/xml":                   true,
+	"application/x-ndjson":              true,
+	"application/yaml":                  true,
+	"application/x-yaml":                true,
+	"application/toml":                  true,
+	"application/x-www-form-urlencoded": true,
+	"image/svg+xml":                     true,
+}
+
+// isCompressible reports whether content of the given Content-Type benefits
+// from compression: text/*, JSON, XML and a few other textual formats.
+func isCompressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml") ||
+		compressibleTypes[mediaType]
+}
+
+// acceptsGzip reports whether an Accept-Encoding header accepts gzip with a
+// non-zero quality, either by name or through "*".
+func acceptsGzip(acceptEncoding string) bool {
+	gzipQ, anyQ := -1.0, -1.0
+	for part := range strings.SplitSeq(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = f
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case "gzip", "x-gzip":
+			gzipQ = q
+		case "*":
+			anyQ = q
+		}
+	}
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+	return anyQ > 0
+}
+
+// renderRange writes a single range of the content as a 206 Partial Content.
+func (r Reader) renderRange(w http.ResponseWriter, seeker io.ReadSeeker, ra httpRange) error {
+	r.WriteContentType(w)
+	r.writeHeaders(w)
+	header := w.Header()
+	header.Set("Content-Range", ra.contentRange(r.ContentLength))
+	header.Set("Content-Length", strconv.FormatInt(ra.length, 10))
+	w.WriteHeader(http.StatusPartialContent)
+
+	if _, err := seeker.Seek(ra.start, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := io.CopyN(w, seeker, ra.length)
+	return err
+}
+
+// renderMultiRange writes several ranges of the content as a multipart/byteranges
+// 206 Partial Content, each part carrying its own Content-Range and Content-Type.
+func (r Reader) renderMultiRange(w http.ResponseWriter, seeker io.ReadSeeker, ranges []httpRange) error {
+	r.writeHeaders(w)
+	mw := multipart.NewWriter(w)
+	header := w.Header()
+	header.Set("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+	header.Del("Content-Length")
+	w.WriteHeader(http.StatusPartialContent)
+
+	for i, ra := range ranges {
+		partHeader := textproto.MIMEHeader{}
+		contentType := r.ContentType
+		if r.PartContentType != nil {
+			if ct := r.PartContentType(i, ra.start, ra.start+ra.length-1); ct != "" {
+				contentType = ct
+			}
+		}
+		if contentType != "" {
+			partHeader.Set("Content-Type", contentType)
+		}
+		partHeader.Set("Content-Range", ra.contentRange(r.ContentLength))
+		part, err := mw.CreatePart(partHeader)
+		if err != nil {
+			return err
+		}
+		if _, err := seeker.Seek(ra.start, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(part, seeker, ra.length); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+// maxRanges is the most ranges of a Range header served as multipart/byteranges.
+const maxRanges = 100
+
+// rangesServable reports whether ranges are few enough, do not overlap and do
+// not add up to more than the content, as net/http's ServeContent requires.
+func rangesServable(ranges []httpRange, size int64) bool {
+	if len(ranges) > maxRanges {
+		return false
+	}
+	sorted := slices.SortedFunc(slices.Values(ranges), func(a, b httpRange) int {
+		return cmp.Compare(a.start, b.start)
+	})
+	var total int64
+	for i, ra := range sorted {
+		if i > 0 && ra.start < sorted[i-1].start+sorted[i-1].length {
+			return false
+		}
+		total += ra.length
+	}
+	return total <= size
+}
+
+// parseRange parses a `bytes=` Range header against content of the given size.
+// Ranges that start beyond the content are dropped; if none remain
+// errUnsatisfiableRange is returned.
+func parseRange(s string, size int64) ([]httpRange, error) {
+	spec, ok := strings.CutPrefix(s, "bytes=")
+	if !ok {
+		return nil, errors.New("invalid range unit")
+	}
+
+	var ranges []httpRange
+	for part := range strings.SplitSeq(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, errors.New("invalid range")
+		}
+		first, last = strings.TrimSpace(first), strings.TrimSpace(last)
+
+		var ra httpRange
+		if first == "" {
+			// suffix range, the last n bytes
+			n, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || n < 0 {
+				return nil, errors.New("invalid range")
+			}
+			if n == 0 {
+				continue
+			}
+			n = min(n, size)
+			ra = httpRange{start: size - n, length: n}
+		} else {
+			start, err := strconv.ParseInt(first, 10, 64)
+			if err != nil || start < 0 {
+				return nil, errors.New("invalid range")
+			}
+			if start >= size {
+				continue
+			}
+			end := size - 1
+			if last != "" {
+				end, err = strconv.ParseInt(last, 10, 64)
+				if err != nil || end < start {
+					return nil, errors.New("invalid range")
+				}
+				end = min(end, size-1)
+			}
+			ra = httpRange{start: start, length: end - start + 1}
+		}
+		ranges = append(ranges, ra)
+	}
+	if len(ranges) == 0 {
+		return nil, errUnsatisfiableRange
+	}
+	return ranges, nil
+}
+
+// etag returns the ETag of the content, computing a weak one if enabled.
+func (r Reader) etag() (string, error) {
+	seeker, ok := r.Reader.(io.ReadSeeker)
+	if r.ETag != "" || !r.WeakETag || !ok || r.ContentLength < 0 {
+		return r.ETag, nil
+	}
+
+	crc := crc32.NewIEEE()
+	if _, err := io.CopyN(crc, seeker, min(r.ContentLength, weakETagSampleSize)); err != nil {
+		return "", err
+	}
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`W/"%x-%08x"`, r.ContentLength, crc.Sum32()), nil
+}
+
 // WriteContentType (Reader) writes custom ContentType.
 func (r Reader) WriteContentType(w http.ResponseWriter) {
 	writeContentType(w, []string{r.ContentType})
@@ -12,10 +12,16 @@
 package render
 
 import (
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,5 +36,112 @@ func TestReaderRenderNoHeaders(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReaderRenderETag(t *testing.T) {
+	content := "test"
+	r := Reader{
+		ContentLength: int64(len(content)),
+		Reader:        strings.NewReader(content),
+		ETag:          `"v1"`,
+		Request:       conditionalRequest(http.MethodGet, "If-None-Match", `"v0", W/"v1"`),
+	}
+	w := httptest.NewRecorder()
+	require.NoError(t, r.Render(w))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+
+	r.Reader = strings.NewReader(content)
+	r.Request = conditionalRequest(http.MethodGet, "If-None-Match", `"v0"`)
+	w = httptest.NewRecorder()
+	require.NoError(t, r.Render(w))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, content, w.Body.String())
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+}
+
+func TestReaderRenderWeakETag(t *testing.T) {
+	content := "some static asset"
+	w := httptest.NewRecorder()
+	r := Reader{
+		ContentLength: int64(len(content)),
+		Reader:        strings.NewReader(content),
+		WeakETag:      true,
+	}
+	require.NoError(t, r.Render(w))
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, fmt.Sprintf(`W/"%x-%08x"`, len(content), crc32.ChecksumIEEE([]byte(content))), etag)
+	assert.Equal(t, content, w.Body.String(), "the content is rewound after hashing")
+
+	r.Reader = strings.NewReader(content)
+	r.Request = conditionalRequest(http.MethodGet, "If-None-Match", etag)
+	w = httptest.NewRecorder()
+	require.NoError(t, r.Render(w))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// Readers that cannot be rewound get no weak ETag
+	r.Reader = io.LimitReader(strings.NewReader(content), int64(len(content)))
+	w = httptest.NewRecorder()
+	require.NoError(t, r.Render(w))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Equal(t, content, w.Body.String())
+}
+
+func conditionalRequest(method, key, value string) *http.Request {
+	req := httptest.NewRequest(method, "/", nil)
+	req.Header.Set(key, value)
+	return req
+}
+
+func TestReaderRenderConditional(t *testing.T) {
+	content := "test"
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"if-modified-since not modified", conditionalRequest(http.MethodGet, "If-Modified-Since", lastModified.Format(http.TimeFormat)), http.StatusNotModified},
+		{"if-modified-since modified", conditionalRequest(http.MethodGet, "If-Modified-Since", lastModified.Add(-time.Hour).Format(http.TimeFormat)), http.StatusOK},
+		{"if-match miss", conditionalRequest(http.MethodGet, "If-Match", `"v2"`), http.StatusPreconditionFailed},
+		{"if-match hit", conditionalRequest(http.MethodGet, "If-Match", `"v1"`), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			require.NoError(t, (Reader{
+				ContentLength: int64(len(content)),
+				Reader:        strings.NewReader(content),
+				ETag:          `"v1"`,
+				LastModified:  lastModified,
+				Request:       tt.req,
+			}).Render(w))
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, lastModified.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+			if tt.status == http.StatusOK {
+				assert.Equal(t, content, w.Body.String())
+			} else {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestReaderRenderConditionalNoETag(t *testing.T) {
+	content := "test"
+	for _, ifNoneMatch := range []string{`"abc",`, `,`, `"abc", ,"def"`} {
+		w := httptest.NewRecorder()
+		require.NoError(t, (Reader{
+			ContentLength: int64(len(content)),
+			Reader:        strings.NewReader(content),
+			Request:       conditionalRequest(http.MethodGet, "If-None-Match", ifNoneMatch),
+		}).Render(w))
+		assert.Equal(t, http.StatusOK, w.Code, ifNoneMatch)
+		assert.Equal(t, content, w.Body.String(), ifNoneMatch)
+	}
+}
+
 
 /* EOF - universal-crypto-mcp | 0xN1CH */
\ No newline at end of file
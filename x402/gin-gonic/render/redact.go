@@ -0,0 +1,215 @@
+/* redact.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"bytes"
+	"encoding"
+	stdjson "encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// RedactedValue replaces the value of fields tagged `sensitive:"true"` in RedactedJSON.
+const RedactedValue = "[REDACTED]"
+
+// RedactedJSON contains the given interface object. When Redact is set,
+// struct fields tagged `sensitive:"true"` are rendered as RedactedValue.
+type RedactedJSON struct {
+	Data   any
+	Redact bool
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[stdjson.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// Render (RedactedJSON) marshals the given interface object and writes it with custom ContentType.
+func (r RedactedJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	if !r.Redact {
+		return WriteJSON(w, r.Data)
+	}
+
+	var buf bytes.Buffer
+	if err := writeRedacted(&buf, reflect.ValueOf(r.Data)); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteContentType (RedactedJSON) writes JSON ContentType.
+func (r RedactedJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonContentType)
+}
+
+// writeRedacted encodes v like the json codec would, walking structs, slices
+// and maps so nested sensitive fields are redacted as well.
+func writeRedacted(buf *bytes.Buffer, v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+			break
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return writeMarshaled(buf, v)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		buf.WriteByte('{')
+		first := true
+		if err := writeRedactedFields(buf, v, &first); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+		return nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8) {
+			return writeMarshaled(buf, v)
+		}
+		buf.WriteByte('[')
+		for i := range v.Len() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeRedacted(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return writeMarshaled(buf, v)
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeMarshaled(buf, reflect.ValueOf(key.String())); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeRedacted(buf, v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	default:
+		return writeMarshaled(buf, v)
+	}
+}
+
+// writeRedactedFields writes the exported fields of struct v, honoring json
+// tags and inlining untagged embedded structs.
+func writeRedactedFields(buf *bytes.Buffer, v reflect.Value, first *bool) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				ft, fv = ft.Elem(), fv.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := writeRedactedFields(buf, fv, first); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && isEmptyValue(fv) {
+			continue
+		}
+
+		if !*first {
+			buf.WriteByte(',')
+		}
+		*first = false
+		if err := writeMarshaled(buf, reflect.ValueOf(name)); err != nil {
+			return err
+		}
+		buf.WriteByte(':')
+
+		if sensitive, _ := strconv.ParseBool(field.Tag.Get("sensitive")); sensitive {
+			if err := writeMarshaled(buf, reflect.ValueOf(RedactedValue)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeRedacted(buf, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarshaled(buf *bytes.Buffer, v reflect.Value) error {
+	data, err := json.API.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// isEmptyValue reports whether v is empty in the sense of the json omitempty option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
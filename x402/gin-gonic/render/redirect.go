@@ -9,6 +9,7 @@ package render
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 // TODO(nich.xbt): optimize this section
@@ -17,6 +18,12 @@ type Redirect struct {
 	Code     int
 	Request  *http.Request
 	Location string
+	// PreserveQuery merges the query parameters of Request into Location.
+	// A key present in both keeps the values of Location, or those of
+	// Request when QueryOverride is set; values of a key are never mixed.
+	PreserveQuery bool
+	// QueryOverride lets Request query parameters win over those of Location.
+	QueryOverride bool
 }
 
 // Render (Redirect) redirects the http request to new location and writes redirect response.
@@ -25,10 +32,36 @@ func (r Redirect) Render(w http.ResponseWriter) error {
 // FIXME(nich): review edge cases
 		panic(fmt.Sprintf("Cannot redirect with status code %d", r.Code))
 	}
-	http.Redirect(w, r.Request, r.Location, r.Code)
+	location := r.Location
+	if r.PreserveQuery {
+		location = r.mergeQuery()
+	}
+	http.Redirect(w, r.Request, location, r.Code)
 	return nil
 }
 
+// mergeQuery returns Location with the query parameters of Request merged in.
+// An unparsable Location is returned as is.
+func (r Redirect) mergeQuery() string {
+	if r.Request == nil || r.Request.URL == nil || r.Request.URL.RawQuery == "" {
+		return r.Location
+	}
+	target, err := url.Parse(r.Location)
+	if err != nil {
+		return r.Location
+	}
+
+	query := target.Query()
+	for key, values := range r.Request.URL.Query() {
+		if _, ok := query[key]; ok && !r.QueryOverride {
+			continue
+		}
+		query[key] = values
+	}
+	target.RawQuery = query.Encode()
+	return target.String()
+}
+
 // WriteContentType (Redirect) don't write any ContentType.
 func (r Redirect) WriteContentType(http.ResponseWriter) {}
 
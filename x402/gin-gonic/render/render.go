@@ -33,6 +33,25 @@ var (
 	_ Render     = (*AsciiJSON)(nil)
 	_ Render     = (*ProtoBuf)(nil)
 	_ Render     = (*TOML)(nil)
+	_ Render     = (*CBOR)(nil)
+	_ Render     = (*Envelope)(nil)
+	_ Render     = (*Markdown)(nil)
+	_ Render     = (*RedactedJSON)(nil)
+	_ Render     = (*Bytes)(nil)
+	_ Render     = (*FramedBinary)(nil)
+	_ Render     = (*Proxy)(nil)
+	_ Render     = (*ProtoStream)(nil)
+	_ Render     = (*Problem)(nil)
+	_ Render     = (*ProblemXML)(nil)
+	_ Render     = (*SSEStream)(nil)
+	_ Render     = (*JSONSchema)(nil)
+	_ Render     = (*SecurityHeaders)(nil)
+	_ Render     = (*NDJSON)(nil)
+	_ Render     = (*Representation)(nil)
+	_ Render     = (*CSV)(nil)
+	_ Render     = (*SparseJSON)(nil)
+	_ Render     = (*StaticGzip)(nil)
+	_ Render     = (*RateLimited)(nil)
 )
 
 func writeContentType(w http.ResponseWriter, value []string) {
@@ -11,6 +11,7 @@ package render
 import (
 	"bytes"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,5 +47,42 @@ func TestRenderMsgPack(t *testing.T) {
 	assert.Equal(t, "application/msgpack; charset=utf-8", w.Header().Get("Content-Type"))
 }
 
+func TestRenderMsgPackErrors(t *testing.T) {
+	type payload struct {
+		Name    string
+		Updates chan int
+	}
+	type ignored struct {
+		Name    string
+		Updates chan int `codec:"-"`
+	}
+
+	for _, data := range []any{
+		nil,
+		make(chan int),
+		func() {},
+		map[string]any{"updates": make(chan int)},
+		[]any{1, func() {}},
+		&payload{Name: "a"},
+		complex(1, 2),
+	} {
+		w := httptest.NewRecorder()
+		require.Error(t, (MsgPack{data}).Render(w), "%T", data)
+		assert.Empty(t, w.Body.Bytes())
+	}
+
+	w := httptest.NewRecorder()
+	require.NoError(t, (MsgPack{ignored{Name: "a"}}).Render(w))
+	assert.NotEmpty(t, w.Body.Bytes())
+
+	// Cyclic pointers are checked once
+	type node struct {
+		Next *node
+	}
+	n := &node{}
+	n.Next = n
+	require.NoError(t, checkMsgPackValue(reflect.ValueOf(n), map[uintptr]bool{}))
+}
+
 
 /* universal-crypto-mcp © nicholas */
\ No newline at end of file
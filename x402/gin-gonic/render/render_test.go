@@ -7,20 +7,34 @@
 package render
 
 import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	stdjson "encoding/json"
 	"encoding/xml"
 	"errors"
 	"html/template"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin/codec/json"
 	testdata "github.com/gin-gonic/gin/testdata/protoexample"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -52,6 +66,66 @@ func TestRenderJSONError(t *testing.T) {
 	require.Error(t, (JSON{data}).Render(w))
 }
 
+func TestRenderEnvelope(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := (Envelope{Success: true, Data: map[string]any{"foo": "bar"}}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"success":true,"data":{"foo":"bar"},"error":null}`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
+func TestRenderEnvelopeError(t *testing.T) {
+	w := httptest.NewRecorder()
+	envelope := Envelope{
+		Fields: EnvelopeFields{Success: "ok", Error: "message"},
+		Error:  errors.New("not found"),
+	}
+
+	err := envelope.Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"ok":false,"data":null,"message":"not found"}`, w.Body.String())
+
+	// json: unsupported type: chan int
+	require.Error(t, (Envelope{Data: make(chan int)}).Render(httptest.NewRecorder()))
+}
+
+type redactedCard struct {
+	Number string `json:"number" sensitive:"true"`
+	Brand  string `json:"brand"`
+}
+
+type redactedUser struct {
+	Name     string         `json:"name"`
+	Password string         `json:"password" sensitive:"true"`
+	PIN      int            `json:"pin,omitempty" sensitive:"true"`
+	Cards    []redactedCard `json:"cards"`
+	Internal string         `json:"-"`
+}
+
+func TestRenderRedactedJSON(t *testing.T) {
+	data := redactedUser{
+		Name:     "gopher",
+		Password: "hunter2",
+		PIN:      1234,
+		Cards:    []redactedCard{{Number: "4242", Brand: "visa"}},
+		Internal: "hidden",
+	}
+
+	w := httptest.NewRecorder()
+	err := (RedactedJSON{Data: &data, Redact: true}).Render(w)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"gopher","password":"[REDACTED]","pin":"[REDACTED]","cards":[{"number":"[REDACTED]","brand":"visa"}]}`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = httptest.NewRecorder()
+	err = (RedactedJSON{Data: data}).Render(w)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"gopher","password":"hunter2","pin":1234,"cards":[{"number":"4242","brand":"visa"}]}`, w.Body.String())
+}
+
 func TestRenderIndentedJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	data := map[string]any{
@@ -299,6 +373,35 @@ b:
 	assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
 }
 
+func TestRenderYAMLNonStringKeysAndNesting(t *testing.T) {
+	type leaf struct {
+		Values map[int]string `yaml:"values"`
+	}
+	type node struct {
+		Name  string         `yaml:"name"`
+		Child *node          `yaml:"child,omitempty"`
+		Leaf  leaf           `yaml:"leaf"`
+		Flags map[bool][]int `yaml:"flags"`
+	}
+	data := node{Name: "root", Leaf: leaf{Values: map[int]string{1: "one"}}}
+	parent := &data
+	for i := range 20 {
+		parent.Child = &node{Name: "child" + strconv.Itoa(i), Flags: map[bool][]int{true: {i}}}
+		parent = parent.Child
+	}
+
+	w := httptest.NewRecorder()
+	require.NotPanics(t, func() {
+		require.NoError(t, (YAML{data}).Render(w))
+	})
+
+	output := w.Body.String()
+	assert.Contains(t, output, "1: one")
+	assert.Contains(t, output, "true:")
+	assert.Contains(t, output, "name: child19")
+	assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
 type fail struct{}
 
 // Hook MarshalYAML
@@ -333,6 +436,212 @@ func TestRenderTOMLFail(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestRenderCSV(t *testing.T) {
+	type row struct {
+		Name    string     `csv:"name"`
+		Note    string     `csv:"note"`
+		Amount  *int       `csv:"amount"`
+		At      time.Time  `csv:"at"`
+		Secret  string     `csv:"-"`
+		Updated *time.Time `csv:"updated"`
+	}
+	amount := 5
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := []row{
+		{Name: "alice", Note: "likes, commas", Amount: &amount, At: at, Secret: "x"},
+		{Name: "bob", Note: "two\nlines \"quoted\"", At: at, Updated: &at},
+	}
+
+	w := httptest.NewRecorder()
+	err := (CSV{Data: rows, Filename: "report.csv"}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=report.csv", w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "name,note,amount,at,updated\n"+
+		"alice,\"likes, commas\",5,2024-01-02T03:04:05Z,\n"+
+		"bob,\"two\nlines \"\"quoted\"\"\",,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z\n", w.Body.String())
+}
+
+func TestRenderCSVOptions(t *testing.T) {
+	type row struct {
+		A string
+		B int
+	}
+
+	w := httptest.NewRecorder()
+	err := (CSV{Data: []*row{{A: "x;y", B: 1}, nil}, Comma: ';', OmitHeader: true}).Render(w)
+	require.NoError(t, err)
+	assert.Equal(t, "\"x;y\";1\n", w.Body.String())
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+
+	w = httptest.NewRecorder()
+	err = (CSV{Data: [][]string{{"a", "b"}, {"1", "2,3"}}}).Render(w)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n1,\"2,3\"\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	err = (CSV{Data: []string{"a"}}).Render(w)
+	require.ErrorIs(t, err, errCSVData)
+}
+
+func TestRenderSparseJSON(t *testing.T) {
+	type owner struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	type item struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+		Owner owner  `json:"owner"`
+	}
+	data := item{ID: 1, Name: "gin", Price: 10, Owner: owner{Name: "alice", Email: "a@example.com"}}
+
+	w := httptest.NewRecorder()
+	err := (SparseJSON{Data: data, Fields: []string{"id,owner.email", "unknown", "name.first"}}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"id":1,"owner":{"email":"a@example.com"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	err = (SparseJSON{Data: []item{data, data}, Fields: []string{"name", "owner"}}).Render(w)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"gin","owner":{"name":"alice","email":"a@example.com"}},{"name":"gin","owner":{"name":"alice","email":"a@example.com"}}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	err = (SparseJSON{Data: data}).Render(w)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"name":"gin","price":10,"owner":{"name":"alice","email":"a@example.com"}}`, w.Body.String())
+}
+
+func TestNegotiator(t *testing.T) {
+	n := NewNegotiator()
+	data := map[string]any{"foo": "bar"}
+
+	tests := []struct {
+		accept   string
+		expected Render
+	}{
+		{"application/cbor;q=0.9, application/json;q=0.8", CBOR{Data: data}},
+		{"application/json;q=0.5, application/toml", TOML{Data: data}},
+		{"text/*;q=0.7, */*;q=0.1", XML{Data: data}},
+		{"*/*", JSON{Data: data}},
+		{"*/*;q=0.5, application/yaml;q=0.5", YAML{Data: data}},
+		{"", JSON{Data: data}},
+		{"image/png", JSON{Data: data}},
+		{"application/json;q=0, application/xml;q=0", JSON{Data: data}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, n.Render(tt.accept, data), tt.accept)
+	}
+
+	_, _, ok := n.Negotiate("image/png")
+	assert.False(t, ok)
+
+	// custom media types take part in negotiation
+	n.Register("text/csv", func(data any) Render { return CSV{Data: data} })
+	mediaType, fn, ok := n.Negotiate("text/csv, application/json;q=0.9")
+	require.True(t, ok)
+	assert.Equal(t, "text/csv", mediaType)
+	assert.Equal(t, CSV{Data: data}, fn(data))
+}
+
+func TestRenderCBOR(t *testing.T) {
+	type inner struct {
+		Tags []string `cbor:"tags"`
+	}
+	type outer struct {
+		Name  string `cbor:"name"`
+		Inner inner  `cbor:"inner"`
+	}
+	data := outer{Name: "sensor", Inner: inner{Tags: []string{"a", "b"}}}
+
+	w := httptest.NewRecorder()
+	(CBOR{data}).WriteContentType(w)
+	assert.Equal(t, "application/cbor", w.Header().Get("Content-Type"))
+
+	require.NoError(t, (CBOR{data}).Render(w))
+	var got outer
+	require.NoError(t, cbor.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, data, got)
+
+	var keys map[string]any
+	require.NoError(t, cbor.Unmarshal(w.Body.Bytes(), &keys))
+	assert.Contains(t, keys, "inner")
+}
+
+func TestRenderCBORFail(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := (CBOR{make(chan int)}).Render(w)
+	require.Error(t, err)
+}
+
+func TestAddVary(t *testing.T) {
+	header := http.Header{}
+	AddVary(header, "Accept")
+	assert.Equal(t, []string{"Accept"}, header.Values("Vary"))
+
+	AddVary(header, "accept", "Accept-Encoding", "ACCEPT-ENCODING")
+	assert.Equal(t, []string{"Accept, Accept-Encoding"}, header.Values("Vary"))
+
+	// Separate Vary lines are merged into one.
+	header = http.Header{}
+	header.Add("Vary", "Origin")
+	header.Add("Vary", "Accept-Language, origin")
+	AddVary(header, "Accept")
+	assert.Equal(t, []string{"Origin, Accept-Language, Accept"}, header.Values("Vary"))
+
+	// A wildcard already covers every field.
+	header = http.Header{}
+	header.Set("Vary", "*")
+	AddVary(header, "Accept")
+	assert.Equal(t, []string{"*"}, header.Values("Vary"))
+	header = http.Header{}
+	header.Set("Vary", "Accept")
+	AddVary(header, "*")
+	assert.Equal(t, []string{"*"}, header.Values("Vary"))
+
+	header = http.Header{}
+	AddVary(header)
+	assert.Empty(t, header.Values("Vary"))
+}
+
+func TestRenderRepresentation(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := Representation{Location: "/items/1", ContentLocation: "/items/1", Data: map[string]any{"id": 1}}
+	require.NoError(t, r.Render(w))
+	assert.Equal(t, "/items/1", w.Header().Get("Location"))
+	assert.Equal(t, "/items/1", w.Header().Get("Content-Location"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"id":1}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	(Representation{}).WriteContentType(w)
+	assert.Empty(t, w.Header().Get("Location"))
+	assert.Empty(t, w.Header().Get("Content-Location"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
+func TestRenderRateLimited(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := RateLimited{RetryAfter: 1500 * time.Millisecond, Info: RateLimitInfo{Limit: 100, Remaining: -1, Reset: time.Minute}}
+	require.NoError(t, r.Render(w))
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, "100", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+	assert.JSONEq(t, `{"error":"rate limit exceeded","retryAfter":2}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	require.NoError(t, (RateLimited{Data: map[string]string{"error": "slow down"}}).Render(w))
+	assert.Equal(t, "0", w.Header().Get("Retry-After"))
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":"slow down"}`, w.Body.String())
+}
+
 // test Protobuf rendering
 func TestRenderProtoBuf(t *testing.T) {
 	w := httptest.NewRecorder()
@@ -418,6 +727,42 @@ func TestRenderRedirect(t *testing.T) {
 	data2.WriteContentType(w)
 }
 
+func TestRenderRedirectPreserveQuery(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/old?x=1&tag=a&tag=b&page=2", nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		location string
+		override bool
+		expected string
+	}{
+		{"plain target", "/new", false, "/new?page=2&tag=a&tag=b&x=1"},
+		{"target query wins", "/new?page=1&y=2#top", false, "/new?page=1&tag=a&tag=b&x=1&y=2#top"},
+		{"request query wins", "/new?page=1&tag=c", true, "/new?page=2&tag=a&tag=b&x=1"},
+		{"absolute target", "https://example.com/new?y=2", false, "https://example.com/new?page=2&tag=a&tag=b&x=1&y=2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := (Redirect{
+				Code:          http.StatusMovedPermanently,
+				Request:       req,
+				Location:      tt.location,
+				PreserveQuery: true,
+				QueryOverride: tt.override,
+			}).Render(w)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, w.Header().Get("Location"))
+		})
+	}
+
+	// Without PreserveQuery the request query is dropped
+	w := httptest.NewRecorder()
+	require.NoError(t, (Redirect{Code: http.StatusFound, Request: req, Location: "/new"}).Render(w))
+	assert.Equal(t, "/new", w.Header().Get("Location"))
+}
+
 func TestRenderData(t *testing.T) {
 	w := httptest.NewRecorder()
 	data := []byte("#!PNG some raw data")
@@ -432,6 +777,393 @@ func TestRenderData(t *testing.T) {
 	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
 }
 
+func TestRenderBytes(t *testing.T) {
+	w := httptest.NewRecorder()
+	data := []byte(`{"foo":"bar"}`)
+
+	r := NewBytes("application/json", data)
+	err := r.Render(w)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"foo":"bar"}`, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, r.ETag(), w.Header().Get("ETag"))
+	assert.Equal(t, r.ETag(), Bytes{ContentType: "application/json", Data: data}.ETag())
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	assert.True(t, r.Modified(req))
+	req.Header.Set("If-None-Match", `"other", W/`+r.ETag())
+	assert.False(t, r.Modified(req))
+	req.Header.Set("If-None-Match", "*")
+	assert.False(t, r.Modified(req))
+	req.Header.Set("If-None-Match", `"other"`)
+	assert.True(t, r.Modified(req))
+	req.Header.Set("If-Match", `"other"`)
+	assert.False(t, r.Modified(req))
+}
+
+func TestRenderFramedBinary(t *testing.T) {
+	for _, size := range []int{2, 4, 8} {
+		w := httptest.NewRecorder()
+		messages := make(chan []byte, 3)
+		messages <- []byte("hello")
+		messages <- []byte{}
+		messages <- []byte("world!")
+		close(messages)
+
+		err := (FramedBinary{PrefixSize: size, Messages: messages}).Render(w)
+
+		require.NoError(t, err)
+		assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+		assert.True(t, w.Flushed)
+
+		var frames []string
+		body := w.Body.Bytes()
+		for len(body) > 0 {
+			require.GreaterOrEqual(t, len(body), size)
+			var length uint64
+			switch size {
+			case 2:
+				length = uint64(binary.BigEndian.Uint16(body))
+			case 4:
+				length = uint64(binary.BigEndian.Uint32(body))
+			case 8:
+				length = binary.BigEndian.Uint64(body)
+			}
+			body = body[size:]
+			require.GreaterOrEqual(t, uint64(len(body)), length)
+			frames = append(frames, string(body[:length]))
+			body = body[length:]
+		}
+		assert.Equal(t, []string{"hello", "", "world!"}, frames)
+	}
+
+	messages := make(chan []byte)
+	close(messages)
+	require.Error(t, (FramedBinary{PrefixSize: 3, Messages: messages}).Render(httptest.NewRecorder()))
+
+	messages = make(chan []byte, 1)
+	messages <- make([]byte, 0x10000)
+	close(messages)
+	require.Error(t, (FramedBinary{PrefixSize: 2, Messages: messages}).Render(httptest.NewRecorder()))
+}
+
+func TestRenderFramedBinaryFlushEvery(t *testing.T) {
+	w := &flushCountRecorder{ResponseRecorder: httptest.NewRecorder()}
+	messages := make(chan []byte, 10)
+	for range 10 {
+		messages <- []byte("0123456789")
+	}
+	close(messages)
+
+	require.NoError(t, (FramedBinary{Messages: messages, FlushEvery: FlushEvery{Bytes: 50}}).Render(w))
+	assert.Equal(t, 140, w.Body.Len())
+	// 2 flushes every 56 bytes (4 frames of 14 bytes) plus the trailing 2 frames
+	assert.Equal(t, 3, w.flushes)
+}
+
+func TestRenderSSEStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	events := make(chan sse.Event)
+	go func() {
+		events <- sse.Event{Event: "log", Data: "started"}
+		time.Sleep(50 * time.Millisecond)
+		events <- sse.Event{Event: "log", Data: "done"}
+		close(events)
+	}()
+
+	err := SSEStream{Events: events, KeepAlive: 10 * time.Millisecond}.Render(w)
+	require.NoError(t, err)
+	assert.Equal(t, "text/event-stream;charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, "event:log\ndata:started\n\n"+keepAliveComment), body)
+	assert.True(t, strings.HasSuffix(body, keepAliveComment+"event:log\ndata:done\n\n"), body)
+	assert.True(t, w.Flushed)
+
+	// Without keep-alive only events are written
+	w = httptest.NewRecorder()
+	events = make(chan sse.Event, 1)
+	events <- sse.Event{Data: "line"}
+	close(events)
+	require.NoError(t, SSEStream{Events: events}.Render(w))
+	assert.Equal(t, "data:line\n\n", w.Body.String())
+}
+
+func TestRenderSSEStreamFlushEvery(t *testing.T) {
+	w := &flushCountRecorder{ResponseRecorder: httptest.NewRecorder()}
+	events := make(chan sse.Event, 5)
+	for range 5 {
+		events <- sse.Event{Data: "line"}
+	}
+	close(events)
+
+	require.NoError(t, SSEStream{Events: events, FlushEvery: FlushEvery{Records: 2}}.Render(w))
+	assert.Equal(t, strings.Repeat("data:line\n\n", 5), w.Body.String())
+	// 2 flushes every 2 events plus the trailing event
+	assert.Equal(t, 3, w.flushes)
+}
+
+func TestRenderSSEStreamBinaryData(t *testing.T) {
+	w := httptest.NewRecorder()
+	events := make(chan sse.Event, 2)
+	events <- sse.Event{Event: "chunk", Data: []byte{0x00, 0xff, '\n', 0x10}}
+	events <- sse.Event{Event: "status", Data: "done"}
+	close(events)
+
+	require.NoError(t, SSEStream{Events: events, BinaryData: true}.Render(w))
+	assert.Equal(t, "event:encoding\ndata:base64\n\n"+
+		"event:chunk\ndata:AP8KEA==\n\n"+
+		"event:status\ndata:done\n\n", w.Body.String())
+
+	decoded, err := sse.Decode(strings.NewReader(w.Body.String()))
+	require.NoError(t, err)
+	require.Len(t, decoded, 3)
+	data, err := base64.StdEncoding.DecodeString(decoded[1].Data.(string))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0xff, '\n', 0x10}, data)
+}
+
+func TestRenderNDJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	records := make(chan any, 3)
+	records <- map[string]int{"row": 1}
+	records <- make(chan int)
+	records <- map[string]int{"row": 3}
+	close(records)
+
+	err := NDJSON{Records: records}.Render(w)
+	var recordErr *NDJSONRecordError
+	require.ErrorAs(t, err, &recordErr)
+	assert.Equal(t, 1, recordErr.Index)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"row\":1}\n{\"row\":3}\n", w.Body.String())
+	assert.True(t, w.Flushed)
+
+	// Iterator input
+	w = httptest.NewRecorder()
+	seq := func(yield func(any) bool) {
+		for i := range 3 {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	require.NoError(t, NDJSON{Seq: seq}.Render(w))
+	assert.Equal(t, "0\n1\n2\n", w.Body.String())
+}
+
+func TestRenderNDJSONFlushEvery(t *testing.T) {
+	w := &flushCountRecorder{ResponseRecorder: httptest.NewRecorder()}
+	records := make(chan any, 10)
+	for i := range 10 {
+		records <- i
+	}
+	close(records)
+
+	require.NoError(t, NDJSON{Records: records, FlushEvery: FlushEvery{Records: 4}}.Render(w))
+	assert.Equal(t, "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n", w.Body.String())
+	// 2 flushes every 4 records plus the trailing 2 records
+	assert.Equal(t, 3, w.flushes)
+}
+
+func TestRenderNDJSONContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	records := make(chan any)
+	go func() {
+		records <- "first"
+		cancel()
+	}()
+
+	err := NDJSON{Records: records, Context: ctx}.Render(w)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "\"first\"\n", w.Body.String())
+
+	// An endless iterator stops as well
+	w = httptest.NewRecorder()
+	endless := func(yield func(any) bool) {
+		for yield("row") {
+		}
+	}
+	require.ErrorIs(t, NDJSON{Seq: endless, Context: ctx}.Render(w), context.Canceled)
+}
+
+func TestRenderJSONSchema(t *testing.T) {
+	type address struct {
+		City string `json:"city" binding:"required"`
+	}
+	type user struct {
+		ID        uint64             `json:"id" binding:"required"`
+		Email     string             `json:"email" binding:"required,email"`
+		Nickname  *string            `json:"nickname,omitempty"`
+		Score     float64            `json:"score"`
+		Active    bool               `json:"active"`
+		Tags      []string           `json:"tags"`
+		Labels    map[string]int     `json:"labels"`
+		Address   *address           `json:"address"`
+		CreatedAt time.Time          `json:"createdAt"`
+		Secret    string             `json:"-"`
+		Friends   []user             `json:"friends"`
+		Raw       stdjson.RawMessage `json:"raw"`
+		internal  string
+	}
+
+	w := httptest.NewRecorder()
+	require.NoError(t, JSONSchema{Data: &user{}}.Render(w))
+	assert.Equal(t, "application/schema+json", w.Header().Get("Content-Type"))
+
+	var schema map[string]any
+	require.NoError(t, json.API.Unmarshal(w.Body.Bytes(), &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, "user", schema["title"])
+	assert.Equal(t, []any{"id", "email"}, schema["required"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.Len(t, properties, 11)
+	assert.NotContains(t, properties, "Secret")
+	assert.NotContains(t, properties, "internal")
+	assert.Equal(t, map[string]any{"type": "integer", "minimum": float64(0)}, properties["id"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "email"}, properties["email"])
+	assert.Equal(t, map[string]any{"type": "string"}, properties["nickname"])
+	assert.Equal(t, map[string]any{"type": "number"}, properties["score"])
+	assert.Equal(t, map[string]any{"type": "boolean"}, properties["active"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, properties["tags"])
+	assert.Equal(t, map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "integer"}}, properties["labels"])
+	assert.Equal(t, map[string]any{
+		"type":       "object",
+		"title":      "address",
+		"properties": map[string]any{"city": map[string]any{"type": "string"}},
+		"required":   []any{"city"},
+	}, properties["address"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["createdAt"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "object"}}, properties["friends"])
+	assert.Equal(t, map[string]any{}, properties["raw"])
+}
+
+func TestRenderWithSecurityHeaders(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Security-Policy", "default-src 'none'")
+	r := WithSecurityHeaders(Data{ContentType: "text/html; charset=utf-8", Data: []byte("<p>hi</p>")}, DefaultSecurityHeadersConfig())
+
+	require.NoError(t, r.Render(w))
+	assert.Equal(t, "<p>hi</p>", w.Body.String())
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "max-age=63072000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "strict-origin-when-cross-origin", w.Header().Get("Referrer-Policy"))
+
+	// Empty fields are not sent, also when only the ContentType is written
+	w = httptest.NewRecorder()
+	WithSecurityHeaders(String{Format: "hi"}, SecurityHeadersConfig{ContentTypeOptions: "nosniff"}).WriteContentType(w)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.NotContains(t, w.Header(), "Content-Security-Policy")
+	assert.NotContains(t, w.Header(), "Strict-Transport-Security")
+}
+
+func TestRenderProtoStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	labels := []string{"one", "two", "three"}
+	messages := make(chan proto.Message, len(labels))
+	for i, label := range labels {
+		messages <- &testdata.Test{Label: &label, Reps: []int64{int64(i)}}
+	}
+	close(messages)
+
+	err := (ProtoStream{Messages: messages}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, "application/x-protobuf-stream", w.Header().Get("Content-Type"))
+	assert.True(t, w.Flushed)
+
+	r := bufio.NewReader(w.Body)
+	for i, label := range labels {
+		msg := &testdata.Test{}
+		require.NoError(t, protodelim.UnmarshalFrom(r, msg))
+		assert.Equal(t, label, msg.GetLabel())
+		assert.Equal(t, []int64{int64(i)}, msg.GetReps())
+	}
+	require.ErrorIs(t, protodelim.UnmarshalFrom(r, &testdata.Test{}), io.EOF)
+
+	messages = make(chan proto.Message, 1)
+	messages <- &testdata.Test{}
+	close(messages)
+	require.Error(t, (ProtoStream{Messages: messages}).Render(httptest.NewRecorder()))
+}
+
+func TestRenderProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Upstream", "yes")
+		w.Header().Set("X-Internal", "secret")
+		w.Header().Set("Set-Cookie", "session=1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"proxied":true}`))
+	}))
+	defer upstream.Close()
+
+	resp, err := http.Get(upstream.URL)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	err = (Proxy{Upstream: resp, DenyHeaders: []string{"x-internal", "Set-Cookie"}}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"proxied":true}`, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "yes", w.Header().Get("X-Upstream"))
+	assert.Equal(t, "16", w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Header().Get("X-Internal"))
+	assert.Empty(t, w.Header().Get("Set-Cookie"))
+}
+
+func TestRenderProxyAllowHeaders(t *testing.T) {
+	resp := &http.Response{
+		StatusCode:    http.StatusOK,
+		ContentLength: -1,
+		Header: http.Header{
+			"Content-Type":      {"text/plain"},
+			"Transfer-Encoding": {"chunked"},
+			"Connection":        {"keep-alive"},
+			"X-Other":           {"dropped"},
+		},
+		Body: io.NopCloser(strings.NewReader("streamed")),
+	}
+
+	w := httptest.NewRecorder()
+	err := (Proxy{Upstream: resp, AllowHeaders: []string{"content-type", "Transfer-Encoding"}}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, "streamed", w.Body.String())
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Transfer-Encoding"))
+	assert.Empty(t, w.Header().Get("Connection"))
+	assert.Empty(t, w.Header().Get("X-Other"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+	assert.True(t, w.Flushed)
+}
+
+func TestRenderProxyFlushEvery(t *testing.T) {
+	body := strings.Repeat("a", 100)
+	resp := &http.Response{
+		StatusCode:    http.StatusOK,
+		ContentLength: -1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(iotest.OneByteReader(strings.NewReader(body))),
+	}
+
+	w := &flushCountRecorder{ResponseRecorder: httptest.NewRecorder()}
+	require.NoError(t, (Proxy{Upstream: resp, FlushEvery: FlushEvery{Bytes: 30}}).Render(w))
+	assert.Equal(t, body, w.Body.String())
+	// 3 flushes at 30 byte boundaries plus the trailing 10 bytes
+	assert.Equal(t, 4, w.flushes)
+}
+
 func TestRenderString(t *testing.T) {
 	w := httptest.NewRecorder()
 
@@ -571,6 +1303,31 @@ func TestRenderHTMLDebugPanics(t *testing.T) {
 	assert.Panics(t, func() { htmlRender.Instance("", nil) })
 }
 
+func TestRenderMarkdown(t *testing.T) {
+	w := httptest.NewRecorder()
+	source := "# Title\n\nHello <script>alert(1)</script> [link](javascript:alert(2))\n\n<img src=x onerror=alert(3)>\n"
+
+	err := (Markdown{Source: source}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<h1>Title</h1>")
+	assert.NotContains(t, w.Body.String(), "<script>")
+	assert.NotContains(t, w.Body.String(), "javascript:")
+	assert.NotContains(t, w.Body.String(), "onerror")
+}
+
+func TestRenderMarkdownRaw(t *testing.T) {
+	w := httptest.NewRecorder()
+	source := "# Title\n\n<script>alert(1)</script>\n"
+
+	err := (Markdown{Source: source, Raw: true}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, source, w.Body.String())
+}
+
 func TestRenderReader(t *testing.T) {
 	w := httptest.NewRecorder()
 
@@ -594,6 +1351,271 @@ func TestRenderReader(t *testing.T) {
 	assert.Equal(t, headers["x-request-id"], w.Header().Get("x-request-id"))
 }
 
+type flushCountRecorder struct {
+	*httptest.ResponseRecorder
+	flushes int
+}
+
+func (r *flushCountRecorder) Flush() {
+	r.flushes++
+}
+
+func TestFlushWriterFlushEvery(t *testing.T) {
+	tests := []struct {
+		every   FlushEvery
+		flushes int
+	}{
+		{FlushEvery{}, 100},
+		{FlushEvery{Records: 10}, 10},
+		{FlushEvery{Records: 50}, 2},
+		{FlushEvery{Bytes: 100}, 10},
+		{FlushEvery{Bytes: 500}, 2},
+	}
+
+	for _, tt := range tests {
+		w := &flushCountRecorder{ResponseRecorder: httptest.NewRecorder()}
+		fw := NewFlushWriter(w, tt.every)
+		for range 100 {
+			_, err := fw.Write([]byte("0123456789"))
+			require.NoError(t, err)
+			fw.EndRecord()
+		}
+		fw.Flush()
+
+		assert.Equal(t, tt.flushes, w.flushes, "FlushEvery %+v", tt.every)
+		assert.Equal(t, 1000, w.Body.Len())
+	}
+}
+
+func TestRenderReaderFlushEvery(t *testing.T) {
+	w := &flushCountRecorder{ResponseRecorder: httptest.NewRecorder()}
+	body := strings.Repeat("a", 1000)
+
+	err := (Reader{
+		ContentLength: -1,
+		ContentType:   "text/plain",
+		Reader:        iotest.OneByteReader(strings.NewReader(body)),
+		FlushEvery:    FlushEvery{Bytes: 300},
+	}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, body, w.Body.String())
+	// 3 flushes at 300 byte boundaries plus the trailing 100 bytes
+	assert.Equal(t, 4, w.flushes)
+}
+
+func TestRenderReaderRange(t *testing.T) {
+	body := "0123456789abcdefghij"
+
+	w := httptest.NewRecorder()
+	err := (Reader{
+		ContentType:   "text/plain",
+		ContentLength: int64(len(body)),
+		Reader:        strings.NewReader(body),
+		Range:         "bytes=5-9",
+	}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "56789", w.Body.String())
+	assert.Equal(t, "bytes 5-9/20", w.Header().Get("Content-Range"))
+	assert.Equal(t, "5", w.Header().Get("Content-Length"))
+
+	w = httptest.NewRecorder()
+	err = (Reader{
+		ContentType:   "text/plain",
+		ContentLength: int64(len(body)),
+		Reader:        strings.NewReader(body),
+		Range:         "bytes=30-",
+	}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+	assert.Equal(t, "bytes */20", w.Header().Get("Content-Range"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestRenderReaderMultiRange(t *testing.T) {
+	body := "0123456789abcdefghij"
+
+	w := httptest.NewRecorder()
+	err := (Reader{
+		ContentType:   "text/plain",
+		ContentLength: int64(len(body)),
+		Reader:        strings.NewReader(body),
+		Range:         "bytes=0-3, -5",
+	}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+
+	mediaType, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/byteranges", mediaType)
+
+	expected := []struct {
+		contentRange string
+		data         string
+	}{
+		{"bytes 0-3/20", "0123"},
+		{"bytes 15-19/20", "fghij"},
+	}
+	mr := multipart.NewReader(w.Body, params["boundary"])
+	for _, want := range expected {
+		part, err := mr.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", part.Header.Get("Content-Type"))
+		assert.Equal(t, want.contentRange, part.Header.Get("Content-Range"))
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		assert.Equal(t, want.data, string(data))
+	}
+	_, err = mr.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestRenderReaderAmplifiedRange(t *testing.T) {
+	body := "0123456789abcdefghij"
+
+	tests := map[string]string{
+		"overlapping":        "bytes=0-,0-,0-,0-",
+		"overlapping suffix": "bytes=0-9,-15",
+		"too many":           "bytes=" + strings.Repeat("0-0,", maxRanges+1),
+	}
+	for name, header := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := (Reader{
+				ContentType:   "text/plain",
+				ContentLength: int64(len(body)),
+				Reader:        strings.NewReader(body),
+				Range:         header,
+			}).Render(w)
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Range"))
+			assert.Equal(t, body, w.Body.String())
+		})
+	}
+}
+
+func TestRenderReaderMultiRangePartContentType(t *testing.T) {
+	body := "0123456789abcdefghij"
+
+	w := httptest.NewRecorder()
+	err := (Reader{
+		ContentType:   "application/octet-stream",
+		ContentLength: int64(len(body)),
+		Reader:        strings.NewReader(body),
+		Range:         "bytes=0-3, 10-12, -2",
+		PartContentType: func(index int, start, end int64) string {
+			switch {
+			case end < 10:
+				return "text/plain"
+			case index == 1:
+				return "image/png"
+			}
+			return ""
+		},
+	}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+
+	_, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	require.NoError(t, err)
+
+	expected := []struct {
+		contentType  string
+		contentRange string
+	}{
+		{"text/plain", "bytes 0-3/20"},
+		{"image/png", "bytes 10-12/20"},
+		{"application/octet-stream", "bytes 18-19/20"},
+	}
+	mr := multipart.NewReader(w.Body, params["boundary"])
+	for _, want := range expected {
+		part, err := mr.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, want.contentType, part.Header.Get("Content-Type"))
+		assert.Equal(t, want.contentRange, part.Header.Get("Content-Range"))
+	}
+	_, err = mr.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestRenderConditionalJSON(t *testing.T) {
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	before := lastModified.Add(-time.Hour).Format(http.TimeFormat)
+	at := lastModified.Format(http.TimeFormat)
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		status  int
+	}{
+		{"no conditions", http.MethodGet, nil, http.StatusOK},
+		{"if-none-match hit", http.MethodGet, map[string]string{"If-None-Match": `"v1"`}, http.StatusNotModified},
+		{"if-none-match weak hit", http.MethodGet, map[string]string{"If-None-Match": `"v0", W/"v1"`}, http.StatusNotModified},
+		{"if-none-match miss", http.MethodGet, map[string]string{"If-None-Match": `"v2"`}, http.StatusOK},
+		{"if-modified-since not modified", http.MethodGet, map[string]string{"If-Modified-Since": at}, http.StatusNotModified},
+		{"if-modified-since modified", http.MethodGet, map[string]string{"If-Modified-Since": before}, http.StatusOK},
+		{"if-none-match overrides if-modified-since", http.MethodGet, map[string]string{"If-None-Match": `"v2"`, "If-Modified-Since": at}, http.StatusOK},
+		{"if-none-match on unsafe method", http.MethodPut, map[string]string{"If-None-Match": `"v1"`}, http.StatusPreconditionFailed},
+		{"if-modified-since ignored on unsafe method", http.MethodPut, map[string]string{"If-Modified-Since": at}, http.StatusOK},
+		{"if-match miss", http.MethodGet, map[string]string{"If-Match": `"v2"`}, http.StatusPreconditionFailed},
+		{"if-match weak never matches", http.MethodGet, map[string]string{"If-Match": `W/"v1"`}, http.StatusPreconditionFailed},
+		{"if-match overrides if-unmodified-since", http.MethodGet, map[string]string{"If-Match": `"v1"`, "If-Unmodified-Since": before}, http.StatusOK},
+		{"if-unmodified-since modified", http.MethodGet, map[string]string{"If-Unmodified-Since": before}, http.StatusPreconditionFailed},
+		{"if-match before if-none-match", http.MethodGet, map[string]string{"If-Match": `"v1"`, "If-None-Match": `"v1"`}, http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			w := httptest.NewRecorder()
+			err := (ConditionalRender{
+				Body:         JSON{Data: map[string]string{"foo": "bar"}},
+				Request:      req,
+				ETag:         `"v1"`,
+				LastModified: lastModified,
+			}).Render(w)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+			assert.Equal(t, at, w.Header().Get("Last-Modified"))
+			if tt.status == http.StatusOK {
+				assert.JSONEq(t, `{"foo":"bar"}`, w.Body.String())
+			} else {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRenderReaderRangeNotSeekable(t *testing.T) {
+	body := "0123456789"
+
+	w := httptest.NewRecorder()
+	err := (Reader{
+		ContentType:   "text/plain",
+		ContentLength: int64(len(body)),
+		Reader:        iotest.OneByteReader(strings.NewReader(body)),
+		Range:         "bytes=0-3",
+	}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.String())
+}
+
 func TestRenderReaderNoContentLength(t *testing.T) {
 	w := httptest.NewRecorder()
 
@@ -617,6 +1639,70 @@ func TestRenderReaderNoContentLength(t *testing.T) {
 	assert.Equal(t, headers["x-request-id"], w.Header().Get("x-request-id"))
 }
 
+func TestRenderReaderGzip(t *testing.T) {
+	body := strings.Repeat(`{"hello":"world"}`, 64)
+
+	w := httptest.NewRecorder()
+	err := (Reader{
+		ContentType:    "application/json; charset=utf-8",
+		ContentLength:  int64(len(body)),
+		Reader:         strings.NewReader(body),
+		Headers:        map[string]string{"Content-Length": strconv.Itoa(len(body))},
+		AcceptEncoding: "deflate, gzip;q=0.8",
+	}).Render(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+
+	gz, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+}
+
+func TestRenderReaderGzipCopyError(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := (Reader{
+		ContentType:    "text/plain",
+		ContentLength:  -1,
+		Reader:         io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errors.New("boom"))),
+		AcceptEncoding: "gzip",
+	}).Render(w)
+
+	require.EqualError(t, err, "boom")
+	// The gzip stream is still closed, so the partial body decodes.
+	gz, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "partial", string(decoded))
+}
+
+func TestRenderReaderNoGzip(t *testing.T) {
+	body := "some text"
+	cases := map[string]Reader{
+		"disabled":       {ContentType: "text/plain", AcceptEncoding: "gzip", DisableCompression: true},
+		"incompressible": {ContentType: "image/png", AcceptEncoding: "gzip"},
+		"not accepted":   {ContentType: "text/plain"},
+		"rejected":       {ContentType: "text/plain", AcceptEncoding: "gzip;q=0, *"},
+	}
+	for name, r := range cases {
+		t.Run(name, func(t *testing.T) {
+			r.ContentLength = int64(len(body))
+			r.Reader = strings.NewReader(body)
+
+			w := httptest.NewRecorder()
+			require.NoError(t, r.Render(w))
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, strconv.Itoa(len(body)), w.Header().Get("Content-Length"))
+			assert.Equal(t, body, w.Body.String())
+		})
+	}
+}
+
 func TestRenderWriteError(t *testing.T) {
 	data := []any{"value1", "value2"}
 	prefix := "my-prefix:"
@@ -0,0 +1,44 @@
+/* representation.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import "net/http"
+
+// Representation renders Data as JSON for handlers that return the resource
+// they created or updated, along with the headers locating it.
+type Representation struct {
+	// Location is the URL of a created resource, sent as the Location header.
+	Location string
+	// ContentLocation is the URL of the resource Data represents, sent as the
+	// Content-Location header.
+	ContentLocation string
+	Data            any
+}
+
+// Render (Representation) writes the location headers and marshals Data as JSON.
+func (r Representation) Render(w http.ResponseWriter) error {
+	r.writeHeaders(w)
+	return WriteJSON(w, r.Data)
+}
+
+// WriteContentType (Representation) writes the location headers and JSON ContentType.
+func (r Representation) WriteContentType(w http.ResponseWriter) {
+	r.writeHeaders(w)
+	writeContentType(w, jsonContentType)
+}
+
+func (r Representation) writeHeaders(w http.ResponseWriter) {
+	if r.Location != "" {
+		w.Header().Set("Location", r.Location)
+	}
+	if r.ContentLocation != "" {
+		w.Header().Set("Content-Location", r.ContentLocation)
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
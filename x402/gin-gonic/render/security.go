@@ -0,0 +1,75 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import "net/http"
+
+// SecurityHeadersConfig is a bundle of security headers. Empty fields are not sent.
+type SecurityHeadersConfig struct {
+	// ContentSecurityPolicy is sent as Content-Security-Policy.
+	ContentSecurityPolicy string
+	// StrictTransportSecurity is sent as Strict-Transport-Security.
+	StrictTransportSecurity string
+	// ContentTypeOptions is sent as X-Content-Type-Options.
+	ContentTypeOptions string
+	// ReferrerPolicy is sent as Referrer-Policy.
+	ReferrerPolicy string
+}
+
+// DefaultSecurityHeadersConfig returns a restrictive bundle suited to HTML
+// pages that only load resources from their own origin.
+func DefaultSecurityHeadersConfig() SecurityHeadersConfig {
+	return SecurityHeadersConfig{
+		ContentSecurityPolicy:   "default-src 'self'",
+		StrictTransportSecurity: "max-age=63072000; includeSubDomains",
+		ContentTypeOptions:      "nosniff",
+		ReferrerPolicy:          "strict-origin-when-cross-origin",
+	}
+}
+
+// SecurityHeaders sets the headers of Config before rendering Inner. Like
+// the ContentType, a header already set on the response is not overwritten.
+type SecurityHeaders struct {
+	Inner  Render
+	Config SecurityHeadersConfig
+}
+
+// WithSecurityHeaders wraps inner so that the headers of config are set
+// before it is rendered.
+func WithSecurityHeaders(inner Render, config SecurityHeadersConfig) SecurityHeaders {
+	return SecurityHeaders{Inner: inner, Config: config}
+}
+
+// Render (SecurityHeaders) sets the security headers and renders Inner.
+func (r SecurityHeaders) Render(w http.ResponseWriter) error {
+	r.writeHeaders(w)
+	return r.Inner.Render(w)
+}
+
+// WriteContentType (SecurityHeaders) sets the security headers and writes the
+// ContentType of Inner.
+func (r SecurityHeaders) WriteContentType(w http.ResponseWriter) {
+	r.writeHeaders(w)
+	r.Inner.WriteContentType(w)
+}
+
+func (r SecurityHeaders) writeHeaders(w http.ResponseWriter) {
+	header := w.Header()
+	for key, value := range map[string]string{
+		"Content-Security-Policy":   r.Config.ContentSecurityPolicy,
+		"Strict-Transport-Security": r.Config.StrictTransportSecurity,
+		"X-Content-Type-Options":    r.Config.ContentTypeOptions,
+		"Referrer-Policy":           r.Config.ReferrerPolicy,
+	} {
+		if value != "" && len(header[key]) == 0 {
+			header[key] = []string{value}
+		}
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
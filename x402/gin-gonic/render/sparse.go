@@ -0,0 +1,145 @@
+/* sparse.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin/codec/json"
+)
+
+// SparseJSON contains the given interface object, trimmed to the selected
+// fields. Fields are top-level object keys or dotted paths into nested
+// objects, e.g. "owner.email"; entries may hold several comma separated
+// fields, as in a `?fields=id,name` query. Arrays are trimmed element by
+// element and unknown fields are ignored. No fields renders Data in full.
+type SparseJSON struct {
+	Data   any
+	Fields []string
+}
+
+// fieldTree is a set of selected field paths. A nil subtree selects the
+// whole value of its key.
+type fieldTree map[string]fieldTree
+
+// Render (SparseJSON) marshals the given interface object, keeps the selected
+// fields and writes them with custom ContentType.
+func (r SparseJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	tree := parseFieldTree(r.Fields)
+	if len(tree) == 0 {
+		return WriteJSON(w, r.Data)
+	}
+
+	data, err := json.API.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	projected, err := projectFields(data, tree)
+	if err != nil {
+		return err
+	}
+	if projected == nil {
+		projected = []byte("null")
+	}
+	_, err = w.Write(projected)
+	return err
+}
+
+// WriteContentType (SparseJSON) writes JSON ContentType.
+func (r SparseJSON) WriteContentType(w http.ResponseWriter) {
+	writeContentType(w, jsonContentType)
+}
+
+func parseFieldTree(fields []string) fieldTree {
+	tree := fieldTree{}
+	for _, entry := range fields {
+		for field := range strings.SplitSeq(entry, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			node := tree
+			keys := strings.Split(field, ".")
+			for i, key := range keys {
+				sub, ok := node[key]
+				if i == len(keys)-1 {
+					node[key] = nil
+					break
+				}
+				if ok && sub == nil {
+					// the whole value is already selected
+					break
+				}
+				if !ok {
+					sub = fieldTree{}
+					node[key] = sub
+				}
+				node = sub
+			}
+		}
+	}
+	return tree
+}
+
+// projectFields keeps the fields of tree in the JSON value data. Objects keep
+// the selected keys only and arrays are projected element by element; any
+// other value cannot hold the selected fields and is dropped, returning nil.
+func projectFields(data []byte, tree fieldTree) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	switch trimmed[0] {
+	case '{':
+		var object map[string]stdjson.RawMessage
+		if err := stdjson.Unmarshal(trimmed, &object); err != nil {
+			return nil, err
+		}
+		projected := make(map[string]stdjson.RawMessage, len(tree))
+		for key, sub := range tree {
+			value, ok := object[key]
+			if !ok {
+				continue
+			}
+			if sub != nil {
+				var err error
+				if value, err = projectFields(value, sub); err != nil {
+					return nil, err
+				}
+				if value == nil {
+					continue
+				}
+			}
+			projected[key] = value
+		}
+		return stdjson.Marshal(projected)
+	case '[':
+		var elems []stdjson.RawMessage
+		if err := stdjson.Unmarshal(trimmed, &elems); err != nil {
+			return nil, err
+		}
+		for i, elem := range elems {
+			value, err := projectFields(elem, tree)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				value = stdjson.RawMessage("null")
+			}
+			elems[i] = value
+		}
+		return stdjson.Marshal(elems)
+	}
+	return nil, nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
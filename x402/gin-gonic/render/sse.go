@@ -0,0 +1,89 @@
+/* sse.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+)
+
+// keepAliveComment is an SSE comment line, ignored by EventSource clients.
+const keepAliveComment = ": keep-alive\n\n"
+
+// SSEEncodingEvent is the name of the event that precedes each binary event
+// written by SSEStream with BinaryData set. Its data names the encoding of
+// the next event's data, "base64".
+const SSEEncodingEvent = "encoding"
+
+// SSEStream writes each event received from Events as a Server-Sent Event,
+// flushing after every event by default. Rendering ends once Events is closed.
+type SSEStream struct {
+	Events <-chan sse.Event
+	// KeepAlive sends a keep-alive comment once no event was written for this
+	// long, so that proxies do not close an idle connection. Zero disables it.
+	KeepAlive time.Duration
+	// BinaryData writes events whose Data is a []byte base64 encoded, each
+	// preceded by an SSEEncodingEvent so clients know to decode it.
+	BinaryData bool
+	// FlushEvery controls how often events are flushed. The zero value
+	// flushes after every event. Keep-alive comments are always flushed.
+	FlushEvery FlushEvery
+}
+
+// Render (SSEStream) writes events and keep-alive comments with SSE ContentType.
+func (r SSEStream) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	fw := NewFlushWriter(w, r.FlushEvery)
+
+	var ticker *time.Ticker
+	var idle <-chan time.Time
+	if r.KeepAlive > 0 {
+		ticker = time.NewTicker(r.KeepAlive)
+		defer ticker.Stop()
+		idle = ticker.C
+	}
+
+	for {
+		select {
+		case event, ok := <-r.Events:
+			if !ok {
+				fw.Flush()
+				return nil
+			}
+			if data, ok := event.Data.([]byte); ok && r.BinaryData {
+				if err := sse.Encode(fw, sse.Event{Event: SSEEncodingEvent, Data: "base64"}); err != nil {
+					return err
+				}
+				event.Data = base64.StdEncoding.EncodeToString(data)
+			}
+			if err := sse.Encode(fw, event); err != nil {
+				return err
+			}
+			if ticker != nil {
+				ticker.Reset(r.KeepAlive)
+			}
+			fw.EndRecord()
+		case <-idle:
+			if _, err := io.WriteString(fw, keepAliveComment); err != nil {
+				return err
+			}
+			fw.Flush()
+		}
+	}
+}
+
+// WriteContentType (SSEStream) writes SSE ContentType.
+func (r SSEStream) WriteContentType(w http.ResponseWriter) {
+	sse.Event{}.WriteContentType(w)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
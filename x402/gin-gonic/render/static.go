@@ -0,0 +1,82 @@
+/* static.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+)
+
+// StaticGzip serves a static file through Handler, usually an http.FileServer,
+// gzipping it when Request accepts gzip, the file is compressible (text/*,
+// JSON, JavaScript, CSS, SVG, ...) and at least MinSize bytes long. Images,
+// archives, smaller files, partial or conditional responses and HEAD requests
+// are served as is, so small assets are not worth the CPU and compressed ones
+// not bloated.
+type StaticGzip struct {
+	Handler http.Handler
+	Request *http.Request
+	MinSize int64
+}
+
+// Render (StaticGzip) serves the file, compressed if worth it.
+func (r StaticGzip) Render(w http.ResponseWriter) error {
+	if r.Request.Method == http.MethodHead || !acceptsGzip(r.Request.Header.Get("Accept-Encoding")) {
+		r.Handler.ServeHTTP(w, r.Request)
+		return nil
+	}
+	sw := &staticGzipWriter{ResponseWriter: w, minSize: r.MinSize}
+	r.Handler.ServeHTTP(sw, r.Request)
+	if sw.gz != nil {
+		return sw.gz.Close()
+	}
+	return nil
+}
+
+// WriteContentType (StaticGzip) leaves the Content-Type to Handler.
+func (r StaticGzip) WriteContentType(http.ResponseWriter) {}
+
+// staticGzipWriter decides on compression once the file server wrote the
+// headers of the response.
+type staticGzipWriter struct {
+	http.ResponseWriter
+	minSize     int64
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (w *staticGzipWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	header := w.Header()
+	if code == http.StatusOK && header.Get("Content-Encoding") == "" && isCompressible(header.Get("Content-Type")) {
+		AddVary(header, "Accept-Encoding")
+		if size, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil && size >= w.minSize {
+			header.Set("Content-Encoding", "gzip")
+			header.Del("Content-Length")
+			w.gz = gzip.NewWriter(w.ResponseWriter)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *staticGzipWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
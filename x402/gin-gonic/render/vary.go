@@ -0,0 +1,51 @@
+/* vary.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+// Copyright 2025 Gin Core Team. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"net/http"
+	"strings"
+)
+
+// AddVary adds fields to the Vary header of header, keeping the tokens that
+// are already listed. Fields are compared case-insensitively, so each one is
+// listed only once however many render paths add it. A Vary of "*" is left
+// untouched since it already covers every field.
+func AddVary(header http.Header, fields ...string) {
+	var tokens []string
+	seen := make(map[string]bool)
+	add := func(field string) {
+		field = http.CanonicalHeaderKey(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			return
+		}
+		seen[field] = true
+		tokens = append(tokens, field)
+	}
+
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			add(field)
+		}
+	}
+	if seen["*"] {
+		return
+	}
+	n := len(tokens)
+	for _, field := range fields {
+		add(field)
+	}
+	switch {
+	case seen["*"]:
+		header.Set("Vary", "*")
+	case len(tokens) > n || len(header.Values("Vary")) > 1:
+		header.Set("Vary", strings.Join(tokens, ", "))
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
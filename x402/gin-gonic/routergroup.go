@@ -11,6 +11,8 @@ import (
 	"path"
 	"regexp"
 	"strings"
+
+	"github.com/gin-gonic/gin/render"
 )
 
 var (
@@ -237,6 +239,10 @@ func (group *RouterGroup) createStaticHandler(relativePath string, fs http.FileS
 		}
 		f.Close()
 
+		if minSize := group.engine.StaticGzipMinSize; minSize > 0 {
+			c.Render(-1, render.StaticGzip{Handler: fileServer, Request: c.Request, MinSize: minSize})
+			return
+		}
 		fileServer.ServeHTTP(c.Writer, c.Request)
 	}
 }
@@ -12,11 +12,15 @@
 package gin
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -453,6 +457,58 @@ func TestRouteStaticFileFS(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w3.Code)
 }
 
+func TestRouteStaticGzip(t *testing.T) {
+	dir := t.TempDir()
+	large := strings.Repeat("Gin Web Framework\n", 200)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "large.txt"), []byte(large), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "small.txt"), []byte("Gin"), 0o600))
+	image := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 2048)...)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "image.png"), image, 0o600))
+
+	router := New()
+	router.StaticGzipMinSize = 1024
+	var errs []*Error
+	router.Use(func(c *Context) {
+		c.Next()
+		errs = append(errs, c.Errors...)
+	})
+	router.Static("/static", dir)
+	acceptGzip := header{Key: "Accept-Encoding", Value: "gzip"}
+
+	// a large text file is compressed
+	w := PerformRequest(router, http.MethodGet, "/static/large.txt", acceptGzip)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+	gz, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, large, string(body))
+
+	// images and files below the threshold are served as is
+	for name, content := range map[string]string{"image.png": string(image), "small.txt": "Gin"} {
+		w = PerformRequest(router, http.MethodGet, "/static/"+name, acceptGzip)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, content, w.Body.String())
+	}
+
+	// clients not accepting gzip get the plain file
+	w = PerformRequest(router, http.MethodGet, "/static/large.txt")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, large, w.Body.String())
+
+	// HEAD requests have no body to compress
+	w = PerformRequest(router, http.MethodHead, "/static/large.txt", acceptGzip)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, strconv.Itoa(len(large)), w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Body.String())
+	assert.Empty(t, errs)
+}
+
 // TestHandleStaticDir - ensure the root/sub dir handles properly
 func TestRouteStaticListingDir(t *testing.T) {
 	router := New()
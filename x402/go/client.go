@@ -35,6 +35,8 @@ type x402Client struct {
 	beforePaymentCreationHooks    []BeforePaymentCreationHook
 	afterPaymentCreationHooks     []AfterPaymentCreationHook
 	onPaymentCreationFailureHooks []OnPaymentCreationFailureHook
+
+	retryPolicy RetryPolicy
 }
 
 // ClientOption configures the client
@@ -61,6 +63,7 @@ func Newx402Client(opts ...ClientOption) *x402Client {
 		schemes:              make(map[Network]map[string]SchemeNetworkClient),
 		requirementsSelector: DefaultPaymentSelector,
 		policies:             []PaymentPolicy{},
+		retryPolicy:          DefaultRetryPolicy,
 	}
 
 	for _, opt := range opts {
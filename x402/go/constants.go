@@ -17,6 +17,9 @@ const (
 
 	// ProtocolVersionV1 is the legacy x402 protocol version
 	ProtocolVersionV1 = 1
+
+	// DefaultMaxTimeoutSeconds is the payment timeout of requirements that do not set one
+	DefaultMaxTimeoutSeconds = 60
 )
 
 // Export the main types with uppercase names for external packages
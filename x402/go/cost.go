@@ -0,0 +1,96 @@
+/* cost.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package x402
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// ============================================================================
+// Payment Cost Estimation
+// ============================================================================
+
+// DefaultSettlementDataSize is the assumed size in bytes of a settlement
+// transaction, roughly an EIP-3009 transferWithAuthorization call
+const DefaultSettlementDataSize = 400
+
+// L2CostOracle prices the L1 data a rollup posts for each of its transactions
+type L2CostOracle interface {
+	// L1DataFee returns the fee of posting dataSize bytes of transaction data
+	// to L1, as calldata or blobs, in the same unit as payment amounts
+	L1DataFee(ctx context.Context, network Network, dataSize int) (*big.Int, error)
+}
+
+// CostEstimator estimates the total cost of paying requirements: the amount
+// plus the fee of settling it on its network. Fees must be expressed in the
+// same unit as the amounts being compared, e.g. atomic units of a stablecoin
+//
+// Without an L2 oracle for a network the estimate is the amount plus its
+// execution fee only, which underestimates settlement on rollups
+type CostEstimator struct {
+	// ExecutionFees is the settlement gas fee per network, networks without
+	// an entry are assumed free to settle on
+	ExecutionFees map[Network]*big.Int
+
+	// L2Oracles adds the L1 data fee of settlements on rollup networks
+	L2Oracles map[Network]L2CostOracle
+
+	// SettlementDataSize is the settlement transaction size priced by L2
+	// oracles, DefaultSettlementDataSize when zero
+	SettlementDataSize int
+}
+
+// EstimateCost returns the estimated total cost of paying requirements
+func (e *CostEstimator) EstimateCost(ctx context.Context, requirements PaymentRequirementsView) (*big.Int, error) {
+	cost, ok := new(big.Int).SetString(requirements.GetAmount(), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", requirements.GetAmount())
+	}
+
+	network := Network(requirements.GetNetwork())
+	if fee := e.ExecutionFees[network]; fee != nil {
+		cost.Add(cost, fee)
+	}
+
+	if oracle := e.L2Oracles[network]; oracle != nil {
+		size := e.SettlementDataSize
+		if size <= 0 {
+			size = DefaultSettlementDataSize
+		}
+		fee, err := oracle.L1DataFee(ctx, network, size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate L1 data fee on %s: %w", network, err)
+		}
+		cost.Add(cost, fee)
+	}
+	return cost, nil
+}
+
+// CheapestPaymentSelector chooses the payment option with the lowest cost
+// estimated by estimator. Options whose cost cannot be estimated are only
+// chosen when no estimate succeeds, ties keep the server's order
+func CheapestPaymentSelector(estimator *CostEstimator) PaymentRequirementsSelector {
+	return func(requirements []PaymentRequirementsView) PaymentRequirementsView {
+		if len(requirements) == 0 {
+			panic("no payment requirements available")
+		}
+
+		selected := requirements[0]
+		var lowest *big.Int
+		for _, option := range requirements {
+			cost, err := estimator.EstimateCost(context.Background(), option)
+			if err != nil {
+				continue
+			}
+			if lowest == nil || cost.Cmp(lowest) < 0 {
+				selected, lowest = option, cost
+			}
+		}
+		return selected
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
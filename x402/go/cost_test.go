@@ -0,0 +1,69 @@
+/* cost_test.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package x402
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/x402/go/types"
+)
+
+// mockL2CostOracle charges a fixed fee per byte of L1 data
+type mockL2CostOracle struct {
+	perByte *big.Int
+	err     error
+}
+
+func (m *mockL2CostOracle) L1DataFee(ctx context.Context, network Network, dataSize int) (*big.Int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return new(big.Int).Mul(m.perByte, big.NewInt(int64(dataSize))), nil
+}
+
+func TestCheapestPaymentSelector(t *testing.T) {
+	rollup := types.PaymentRequirements{Scheme: "exact", Network: "eip155:10", Asset: "USDC", Amount: "1000", PayTo: "0xrecipient"}
+	mainnet := types.PaymentRequirements{Scheme: "exact", Network: "eip155:1", Asset: "USDC", Amount: "1100", PayTo: "0xrecipient"}
+	options := []PaymentRequirementsView{mainnet, rollup}
+
+	naive := &CostEstimator{
+		ExecutionFees: map[Network]*big.Int{
+			"eip155:10": big.NewInt(50),
+			"eip155:1":  big.NewInt(100),
+		},
+	}
+	cost, err := naive.EstimateCost(context.Background(), rollup)
+	if err != nil || cost.Cmp(big.NewInt(1050)) != 0 {
+		t.Fatalf("Expected naive rollup cost 1050, got %v, %v", cost, err)
+	}
+	if selected := CheapestPaymentSelector(naive)(options); selected.GetNetwork() != "eip155:10" {
+		t.Errorf("Expected the naive estimate to select the rollup, got %s", selected.GetNetwork())
+	}
+
+	// Posting 400 bytes to L1 adds 400 to the rollup settlement
+	aware := &CostEstimator{
+		ExecutionFees: naive.ExecutionFees,
+		L2Oracles:     map[Network]L2CostOracle{"eip155:10": &mockL2CostOracle{perByte: big.NewInt(1)}},
+	}
+	cost, err = aware.EstimateCost(context.Background(), rollup)
+	if err != nil || cost.Cmp(big.NewInt(1450)) != 0 {
+		t.Fatalf("Expected L2-aware rollup cost 1450, got %v, %v", cost, err)
+	}
+	if selected := CheapestPaymentSelector(aware)(options); selected.GetNetwork() != "eip155:1" {
+		t.Errorf("Expected the L2-aware estimate to select mainnet, got %s", selected.GetNetwork())
+	}
+
+	// Options the oracle cannot price are skipped
+	failing := &CostEstimator{
+		L2Oracles: map[Network]L2CostOracle{"eip155:10": &mockL2CostOracle{err: errors.New("rpc down")}},
+	}
+	if selected := CheapestPaymentSelector(failing)([]PaymentRequirementsView{rollup, mainnet}); selected.GetNetwork() != "eip155:1" {
+		t.Errorf("Expected the unpriced rollup to be skipped, got %s", selected.GetNetwork())
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,108 @@
+/* catalog.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	x402 "github.com/coinbase/x402/go"
+)
+
+// ============================================================================
+// Resource Catalogs
+// ============================================================================
+
+var (
+	// ErrCatalogResourceNotFound is returned when a catalog has no resource
+	// with the requested ID
+	ErrCatalogResourceNotFound = errors.New("resource not found in catalog")
+
+	// ErrCatalogPriceMismatch is returned when the challenge of a catalog
+	// resource asks for another price than the catalog advertised
+	ErrCatalogPriceMismatch = errors.New("challenge price differs from catalog price")
+)
+
+// Catalog is a document listing priced resources, such as a sitemap of paid
+// endpoints browsed by an agent
+type Catalog struct {
+	Resources []CatalogResource `json:"resources"`
+}
+
+// CatalogResource is a priced resource advertised by a Catalog. Scheme,
+// Network and Asset are only checked against the challenge when set
+type CatalogResource struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	Method      string `json:"method,omitempty"` // GET when empty
+	Description string `json:"description,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+	Network     string `json:"network,omitempty"`
+	Asset       string `json:"asset,omitempty"`
+	Amount      string `json:"amount"` // In the asset's smallest unit
+}
+
+// Resource returns the resource of the catalog with the given ID
+func (c *Catalog) Resource(id string) (*CatalogResource, bool) {
+	if c == nil {
+		return nil, false
+	}
+	for i := range c.Resources {
+		if c.Resources[i].ID == id {
+			return &c.Resources[i], true
+		}
+	}
+	return nil, false
+}
+
+// FetchFromCatalog fetches and pays the catalog resource with the given ID.
+// Before anything is signed the challenge is compared with the advertised
+// price, within the price tolerance, and payment is aborted with
+// ErrCatalogPriceMismatch if the server asks for something else
+func (c *x402HTTPClient) FetchFromCatalog(ctx context.Context, catalog *Catalog, resourceID string) (*FetchResult, error) {
+	resource, ok := catalog.Resource(resourceID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrCatalogResourceNotFound, resourceID)
+	}
+
+	method := resource.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, resource.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog resource %q: %w", resourceID, err)
+	}
+	return c.fetch(ctx, req, &fetchState{catalog: resource})
+}
+
+// checkCatalogPrice compares current, the requirements of a challenge, with
+// the catalog resource of a FetchFromCatalog in progress, if any
+func (c *x402HTTPClient) checkCatalogPrice(ctx context.Context, current *PaymentRequirements) error {
+	state, ok := ctx.Value(fetchStateKey{}).(*fetchState)
+	if !ok || state.catalog == nil {
+		return nil
+	}
+	advertised := state.catalog
+
+	for _, field := range []struct{ name, advertised, current string }{
+		{"scheme", advertised.Scheme, current.Scheme},
+		{"network", string(x402.ParseNetwork(advertised.Network)), string(x402.ParseNetwork(current.Network))},
+		{"asset", advertised.Asset, current.Asset},
+	} {
+		if field.advertised != "" && field.advertised != field.current {
+			return fmt.Errorf("%w: %s %q advertised, challenge asks for %q",
+				ErrCatalogPriceMismatch, field.name, field.advertised, field.current)
+		}
+	}
+	if !withinTolerance(advertised.Amount, current.Amount, c.priceTolerance) {
+		return fmt.Errorf("%w: %s advertised, challenge asks for %s",
+			ErrCatalogPriceMismatch, advertised.Amount, current.Amount)
+	}
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -3,14 +3,18 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	x402 "github.com/coinbase/x402/go"
 	"github.com/coinbase/x402/go/types"
@@ -20,16 +24,246 @@ import (
 // x402HTTPClient - HTTP-aware payment client
 // ============================================================================
 
+var (
+	// ErrSpendLimitExceeded is returned when paying a (re-)challenge would push
+	// the total spent on a single request over the configured spend limit
+	ErrSpendLimitExceeded = errors.New("payment would exceed spend limit")
+
+	// ErrPriceAboveMax is returned when a challenge costs more than the
+	// request's MaxPrice and payment is not optional
+	ErrPriceAboveMax = errors.New("payment price above max price")
+
+	// ErrQuotePriceMoved is returned when a stale quote is re-checked before
+	// payment and the price moved beyond the configured tolerance
+	ErrQuotePriceMoved = errors.New("price moved since quote")
+
+	// ErrNoPaymentResponse is returned when a response carries no settlement
+	// header, i.e. nothing was paid
+	ErrNoPaymentResponse = errors.New("payment response header not found")
+
+	// ErrInvalidPaymentResponse is returned when a settlement header is present
+	// but cannot be decoded
+	ErrInvalidPaymentResponse = errors.New("invalid payment response header")
+
+	// errDeclinePayment signals that the 402 is returned to the caller unpaid
+	errDeclinePayment = errors.New("payment declined")
+)
+
 // x402HTTPClient wraps x402Client with HTTP-specific payment handling
 type x402HTTPClient struct {
-	client *x402.X402Client
+	client          *x402.X402Client
+	maxRechallenges int
+	spendLimit      *big.Int
+	preflight       x402.FacilitatorClient
+
+	chain         x402.ChainClient
+	confirmations uint64
+	confirmPoll   time.Duration
+
+	tracer x402.Tracer
+
+	tokenProvider TokenProvider
+
+	sandbox bool
+
+	quoteMaxStaleness time.Duration
+	priceTolerance    float64
+	quotesMu          sync.Mutex
+	quotes            map[string]*PaymentRequirements
+
+	trustedFacilitators []string
+
+	entitlements *entitlementCache
+
+	headerNames PaymentHeaderNames
+
+	priceOracle      PriceOracle
+	maxRateStaleness time.Duration
+
+	idempotency *idempotencyCache
+}
+
+// HTTPClientOption configures an x402HTTPClient
+type HTTPClientOption func(*x402HTTPClient)
+
+// WithMaxRechallenges allows the client to pay again when the server answers a
+// paid request with another 402 at a higher price (e.g. the price changed
+// mid-flight). The default of 0 returns the second 402 to the caller as-is.
+func WithMaxRechallenges(n int) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		if n < 0 {
+			n = 0
+		}
+		c.maxRechallenges = n
+	}
+}
+
+// WithSpendLimit caps the cumulative amount (in atomic units) paid for a
+// single request across the initial challenge and any re-challenges
+func WithSpendLimit(limit *big.Int) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.spendLimit = limit
+	}
+}
+
+// WithPreflightVerify asks the facilitator to verify every signed payment
+// before it is sent, so signature and format problems surface before paying
+func WithPreflightVerify(facilitator x402.FacilitatorClient) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.preflight = facilitator
+	}
+}
+
+// WithOnChainConfirmation makes Fetch independently confirm every settled
+// payment on chain, waiting for the given number of confirmations after the
+// facilitator reported success
+func WithOnChainConfirmation(chain x402.ChainClient, confirmations uint64) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.chain = chain
+		c.confirmations = confirmations
+	}
+}
+
+// WithTracer traces challenge parsing, signing and settlement of every payment
+// The default is x402.NoopTracer
+func WithTracer(tracer x402.Tracer) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		if tracer == nil {
+			tracer = x402.NoopTracer{}
+		}
+		c.tracer = tracer
+	}
+}
+
+// TokenProvider returns the bearer token sent alongside payments, refresh is
+// set when the server rejected the previous token with a 401
+type TokenProvider func(ctx context.Context, refresh bool) (string, error)
+
+// WithTokenProvider sends an `Authorization: Bearer` header from provider on
+// every request that has none, including paid retries. A 401 response asks
+// the provider for a fresh token and retries once
+func WithTokenProvider(provider TokenProvider) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.tokenProvider = provider
+	}
+}
+
+// WithBearerToken sends a static `Authorization: Bearer` token on every request
+func WithBearerToken(token string) HTTPClientOption {
+	return WithTokenProvider(func(ctx context.Context, refresh bool) (string, error) {
+		return token, nil
+	})
+}
+
+// WithQuoteMaxStaleness makes the client remember the requirements returned
+// by Quote. When a quoted resource is paid more than d after it was quoted,
+// the fresh challenge is compared with the quote and payment is aborted with
+// ErrQuotePriceMoved if the price moved beyond the price tolerance
+func WithQuoteMaxStaleness(d time.Duration) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.quoteMaxStaleness = d
+	}
+}
+
+// WithPriceTolerance sets how far the price of a stale quote may move before
+// payment is aborted, as a fraction of the quoted amount (0.05 is 5%)
+// The default of 0 aborts on any change
+func WithPriceTolerance(tolerance float64) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		if tolerance < 0 {
+			tolerance = 0
+		}
+		c.priceTolerance = tolerance
+	}
+}
+
+// PaymentHeaderNames names the headers carrying a payment and its settlement
+type PaymentHeaderNames struct {
+	// Payment carries the signed payment on the paid request
+	Payment string
+	// PaymentResponse carries the settlement on the paid response
+	PaymentResponse string
+}
+
+var (
+	// V1HeaderNames are the header names of x402 version 1
+	V1HeaderNames = PaymentHeaderNames{Payment: "X-PAYMENT", PaymentResponse: "X-PAYMENT-RESPONSE"}
+
+	// V2HeaderNames are the header names of x402 version 2
+	V2HeaderNames = PaymentHeaderNames{Payment: "PAYMENT-SIGNATURE", PaymentResponse: "PAYMENT-RESPONSE"}
+)
+
+// HeaderNamesForVersion returns the header names of an x402 protocol version
+func HeaderNamesForVersion(version int) PaymentHeaderNames {
+	if version == 1 {
+		return V1HeaderNames
+	}
+	return V2HeaderNames
+}
+
+// WithPaymentHeaderNames overrides the header names negotiated from the
+// version of the 402 challenge, for servers expecting non-standard names
+// Empty fields keep the negotiated name
+func WithPaymentHeaderNames(names PaymentHeaderNames) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.headerNames = names
+	}
+}
+
+// paymentHeaderNames returns the header names used for a payment of version
+func (c *x402HTTPClient) paymentHeaderNames(version int) PaymentHeaderNames {
+	names := HeaderNamesForVersion(version)
+	if c.headerNames.Payment != "" {
+		names.Payment = c.headerNames.Payment
+	}
+	if c.headerNames.PaymentResponse != "" {
+		names.PaymentResponse = c.headerNames.PaymentResponse
+	}
+	return names
 }
 
 // Newx402HTTPClient creates a new HTTP-aware x402 client
-func Newx402HTTPClient(client *x402.X402Client) *x402HTTPClient {
-	return &x402HTTPClient{
-		client: client,
+func Newx402HTTPClient(client *x402.X402Client, opts ...HTTPClientOption) *x402HTTPClient {
+	c := &x402HTTPClient{
+		client:      client,
+		confirmPoll: defaultConfirmPollInterval,
+		tracer:      x402.NoopTracer{},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
+}
+
+// ============================================================================
+// Per-request Options
+// ============================================================================
+
+// RequestOptions configures payment handling for a single request
+type RequestOptions struct {
+	// MaxPrice is the highest amount (in atomic units) paid automatically,
+	// nil pays any price within the spend limit
+	MaxPrice *big.Int
+	// PaymentOptional returns the 402 response instead of ErrPriceAboveMax
+	// when the price is above MaxPrice
+	PaymentOptional bool
+	// IdempotencyKey is sent as the IdempotencyKeyHeader of the request when
+	// WithIdempotency is enabled, see NewIdempotencyKey
+	IdempotencyKey string
+}
+
+// requestOptionsKey is the context key for RequestOptions
+type requestOptionsKey struct{}
+
+// WithRequestOptions attaches per-request payment options to ctx
+func WithRequestOptions(ctx context.Context, opts RequestOptions) context.Context {
+	return context.WithValue(ctx, requestOptionsKey{}, opts)
+}
+
+// requestOptionsFromContext returns the options attached with WithRequestOptions
+func requestOptionsFromContext(ctx context.Context) RequestOptions {
+	opts, _ := ctx.Value(requestOptionsKey{}).(RequestOptions)
+	return opts
 }
 
 // ============================================================================
@@ -37,7 +271,8 @@ func Newx402HTTPClient(client *x402.X402Client) *x402HTTPClient {
 // ============================================================================
 
 // EncodePaymentSignatureHeader encodes a payment payload into HTTP headers
-// Returns appropriate headers based on protocol version
+// Returns appropriate headers based on protocol version, unless overridden
+// with WithPaymentHeaderNames
 // Works with raw payload bytes
 func (c *x402HTTPClient) EncodePaymentSignatureHeader(payloadBytes []byte) map[string]string {
 	// Detect version from bytes
@@ -50,13 +285,9 @@ func (c *x402HTTPClient) EncodePaymentSignatureHeader(payloadBytes []byte) map[s
 	encoded := base64.StdEncoding.EncodeToString(payloadBytes)
 
 	switch version {
-	case 2:
-		return map[string]string{
-			"PAYMENT-SIGNATURE": encoded,
-		}
-	case 1:
+	case 1, 2:
 		return map[string]string{
-			"X-PAYMENT": encoded,
+			c.paymentHeaderNames(version).Payment: encoded,
 		}
 	default:
 		panic(fmt.Sprintf("unsupported x402 version: %d", version))
@@ -98,6 +329,13 @@ func (c *x402HTTPClient) GetPaymentSettleResponse(headers map[string]string) (*x
 		normalizedHeaders[strings.ToUpper(k)] = v
 	}
 
+	// Check the configured header
+	if name := c.headerNames.PaymentResponse; name != "" {
+		if header, exists := normalizedHeaders[strings.ToUpper(name)]; exists {
+			return decodePaymentResponseHeader(header)
+		}
+	}
+
 	// Check v2 header
 	if header, exists := normalizedHeaders["PAYMENT-RESPONSE"]; exists {
 		return decodePaymentResponseHeader(header)
@@ -108,7 +346,21 @@ func (c *x402HTTPClient) GetPaymentSettleResponse(headers map[string]string) (*x
 		return decodePaymentResponseHeader(header)
 	}
 
-	return nil, fmt.Errorf("payment response header not found")
+	return nil, ErrNoPaymentResponse
+}
+
+// ParseSettleResponse decodes the settlement header of a paid response, the
+// V2 PAYMENT-RESPONSE header or else the V1 X-PAYMENT-RESPONSE header. It
+// returns ErrNoPaymentResponse when neither is present and an error wrapping
+// ErrInvalidPaymentResponse when the header is not a base64 encoded
+// SettleResponse
+func ParseSettleResponse(header http.Header) (*x402.SettleResponse, error) {
+	for _, name := range []string{"PAYMENT-RESPONSE", "X-PAYMENT-RESPONSE"} {
+		if value := header.Get(name); value != "" {
+			return decodePaymentResponseHeader(value)
+		}
+	}
+	return nil, ErrNoPaymentResponse
 }
 
 // ============================================================================
@@ -131,7 +383,6 @@ func WrapHTTPClientWithPayment(client *http.Client, x402Client *x402HTTPClient)
 	client.Transport = &PaymentRoundTripper{
 		Transport:  originalTransport,
 		x402Client: x402Client,
-		retryCount: &sync.Map{},
 	}
 
 	return client
@@ -141,114 +392,366 @@ func WrapHTTPClientWithPayment(client *http.Client, x402Client *x402HTTPClient)
 type PaymentRoundTripper struct {
 	Transport  http.RoundTripper
 	x402Client *x402HTTPClient
-	retryCount *sync.Map // Track retry count per request to prevent infinite loops
 }
 
-// RoundTrip implements http.RoundTripper with V1/V2 version detection
+// RoundTrip implements http.RoundTripper with V1/V2 version detection.
+// A challenge is only paid when its price is within the request's MaxPrice.
+// A paid request that is answered with another 402 is only paid again when
+// re-challenges are enabled, the new price is higher than the last payment,
+// and the running total stays within the spend limit.
 func (t *PaymentRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	// Get or initialize retry count for this request
-	requestID := fmt.Sprintf("%p", req)
-	count, _ := t.retryCount.LoadOrStore(requestID, 0)
-	retries := count.(int)
+	//nolint:contextcheck // Intentionally using request's context for payment flow
+	ctx := req.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-	// Prevent infinite retry loops
-	if retries > 1 {
-		t.retryCount.Delete(requestID)
-		return nil, fmt.Errorf("payment retry limit exceeded")
+	// Retries with the same idempotency key reuse the payment signed for it
+	idempotency := t.x402Client.idempotency
+	var idempotencyKey string
+	if idempotency != nil {
+		var err error
+		if req, idempotencyKey, err = withIdempotencyKey(ctx, req); err != nil {
+			return nil, err
+		}
 	}
 
-	// Make initial request
-	resp, err := t.Transport.RoundTrip(req)
+	// Make initial request, authenticated if a token provider is configured
+	req, resp, err := t.authorizedRoundTrip(ctx, req)
 	if err != nil {
-		t.retryCount.Delete(requestID)
 		return nil, err
 	}
 
-	// If not 402, return as-is
-	if resp.StatusCode != http.StatusPaymentRequired {
-		t.retryCount.Delete(requestID)
-		return resp, nil
+	// A live entitlement for the resource is used before paying again
+	if resp.StatusCode == http.StatusPaymentRequired {
+		entitledResp, err := t.entitledRoundTrip(req)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if entitledResp != nil {
+			resp.Body.Close()
+			recordPaymentOutcome(ctx, PaymentOutcomeCachedEntitlement)
+			return entitledResp, nil
+		}
 	}
 
-	// Increment retry count
-	t.retryCount.Store(requestID, retries+1)
+	opts := requestOptionsFromContext(ctx)
+	tracer := t.x402Client.tracer
+	spent := new(big.Int)
+	var lastAmount *big.Int
+
+	payments := 0
+	for ; resp.StatusCode == http.StatusPaymentRequired; payments++ {
+		// Prevent infinite re-challenge loops
+		if payments > t.x402Client.maxRechallenges {
+			recordPaymentOutcome(ctx, PaymentOutcomeDeclined)
+			return resp, nil
+		}
 
-	// Extract headers
-	headers := make(map[string]string)
-	for k, v := range resp.Header {
-		if len(v) > 0 {
-			headers[k] = v[0]
+		// The challenge is parsed until requirements are selected
+		_, parseSpan := tracer.Start(ctx, x402.SpanParseChallenge)
+		parsed := false
+		var signSpan x402.Span
+
+		// Extract headers
+		headers := firstHeaderValues(resp.Header)
+
+		// Read response body for V1 support
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			err = fmt.Errorf("failed to read response body: %w", err)
+			endSpan(parseSpan, err)
+			return nil, err
 		}
-	}
 
-	// Read response body for V1 support
-	body, err := io.ReadAll(resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		t.retryCount.Delete(requestID)
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+		// Detect version from response
+		version, err := detectPaymentRequiredVersion(headers, body)
+		if err != nil {
+			err = fmt.Errorf("failed to detect payment version: %w", err)
+			endSpan(parseSpan, err)
+			return nil, err
+		}
+		parseSpan.SetAttribute(x402.AttributeVersion, version)
+
+		// checkPayment decides whether the selected requirements are paid
+		var selected x402.PaymentRequirementsView
+		var reused []byte
+		checkPayment := func(requirements x402.PaymentRequirementsView) error {
+			amount := requirements.GetAmount()
+			value, ok := new(big.Int).SetString(amount, 10)
+
+			// Only top up when the server is asking for more than we last paid;
+			// otherwise the payment was rejected for another reason
+			if payments > 0 && (!ok || lastAmount == nil || value.Cmp(lastAmount) <= 0) {
+				return errDeclinePayment
+			}
 
-	// Detect version from response
-	version, err := detectPaymentRequiredVersion(headers, body)
-	if err != nil {
-		t.retryCount.Delete(requestID)
-		return nil, fmt.Errorf("failed to detect payment version: %w", err)
-	}
+			if opts.MaxPrice != nil && (!ok || value.Cmp(opts.MaxPrice) > 0) {
+				if opts.PaymentOptional {
+					return errDeclinePayment
+				}
+				return fmt.Errorf("%w: price %s, max price %s", ErrPriceAboveMax, amount, opts.MaxPrice)
+			}
 
-	//nolint:contextcheck // Intentionally using request's context for payment flow
-	ctx := req.Context()
-	if ctx == nil {
-		ctx = context.Background()
-	}
+			if limit := t.x402Client.spendLimit; limit != nil {
+				if !ok {
+					return fmt.Errorf("%w: invalid amount %q", ErrSpendLimitExceeded, amount)
+				}
+				if total := new(big.Int).Add(spent, value); total.Cmp(limit) > 0 {
+					return fmt.Errorf("%w: paying %s would bring total to %s, limit is %s",
+						ErrSpendLimitExceeded, value, total, limit)
+				}
+			}
+			if ok {
+				spent.Add(spent, value)
+				lastAmount = value
+			}
+			return nil
+		}
 
-	// Fork based on version
-	var payloadBytes []byte
-	if version == 1 {
-		// V1 flow: body-based PaymentRequired, V1 types
-		payloadBytes, err = t.handleV1Payment(ctx, body)
+		// approve runs once requirements are selected, before anything is signed
+		approve := func(requirements x402.PaymentRequirementsView, resource string) error {
+			selected = requirements
+			setRequirementAttributes(parseSpan, requirements)
+			parseSpan.End()
+			parsed = true
+
+			current := newPaymentRequirements(requirements, resource)
+			if quoteOnly := recordRequirements(ctx, current); quoteOnly {
+				return errDeclinePayment
+			}
+			if err := t.x402Client.checkFacilitator(requirements.GetExtra()); err != nil {
+				return err
+			}
+			if err := t.x402Client.checkQuote(quoteKey(req), current); err != nil {
+				return err
+			}
+			if err := t.x402Client.checkCatalogPrice(ctx, current); err != nil {
+				return err
+			}
+
+			if err := checkPayment(requirements); err != nil {
+				return err
+			}
+			if idempotency != nil {
+				if payload, ok := idempotency.lookup(idempotencyKey, requirementsFingerprint(version, requirements)); ok {
+					reused = payload
+					return errReusePayment
+				}
+			}
+
+			_, signSpan = tracer.Start(ctx, x402.SpanSign)
+			setRequirementAttributes(signSpan, requirements)
+			return nil
+		}
+
+		// Fork based on version
+		var payloadBytes []byte
+		if version == 1 {
+			// V1 flow: body-based PaymentRequired, V1 types
+			payloadBytes, err = t.handleV1Payment(ctx, body, approve)
+		} else {
+			// V2 flow: header-based PaymentRequired, V2 types
+			payloadBytes, err = t.handleV2Payment(ctx, headers, body, approve)
+		}
+		reusing := errors.Is(err, errReusePayment)
+		if reusing {
+			payloadBytes, err = reused, nil
+		}
+		if !parsed {
+			endSpan(parseSpan, err)
+		}
+		if signSpan != nil {
+			endSpan(signSpan, err)
+		}
+		if errors.Is(err, errDeclinePayment) {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			recordPaymentOutcome(ctx, PaymentOutcomeDeclined)
+			return resp, nil
+		}
 		if err != nil {
-			t.retryCount.Delete(requestID)
 			return nil, err
 		}
-	} else {
-		// V2 flow: header-based PaymentRequired, V2 types
-		payloadBytes, err = t.handleV2Payment(ctx, headers, body)
+
+		if t.x402Client.preflight != nil && !t.x402Client.sandbox {
+			if err := t.x402Client.preflightVerify(ctx, payloadBytes, selected); err != nil {
+				return nil, err
+			}
+		}
+		if idempotency != nil && !reusing {
+			idempotency.store(idempotencyKey, requirementsFingerprint(version, selected), payloadBytes)
+		}
+
+		// Encode payment header (works for both V1 and V2)
+		paymentHeaders := t.x402Client.EncodePaymentSignatureHeader(payloadBytes)
+
+		// Create new request with payment header
+		paymentReq := req.Clone(ctx)
+		for k, v := range paymentHeaders {
+			paymentReq.Header.Set(k, v)
+		}
+
+		// Retry with payment
+		_, settleSpan := tracer.Start(ctx, x402.SpanSettle)
+		setRequirementAttributes(settleSpan, selected)
+		resp, err = t.Transport.RoundTrip(paymentReq)
 		if err != nil {
-			t.retryCount.Delete(requestID)
+			endSpan(settleSpan, err)
 			return nil, err
 		}
+		if t.x402Client.sandbox {
+			setSandboxSettlement(resp, t.x402Client.paymentHeaderNames(version), selected)
+		}
+		settlement, err := t.x402Client.GetPaymentSettleResponse(firstHeaderValues(resp.Header))
+		if err == nil {
+			settleSpan.SetAttribute(x402.AttributeTxHash, settlement.Transaction)
+			if !settlement.Success {
+				settleSpan.RecordError(fmt.Errorf("settlement failed: %s", settlement.ErrorReason))
+			} else if cache := t.x402Client.entitlements; cache != nil && resp.StatusCode < http.StatusBadRequest {
+				cache.grant(req, settlement, paymentHeaders)
+			}
+		} else {
+			settlement = nil
+		}
+		if idempotency != nil {
+			idempotency.settled(idempotencyKey, resp, settlement)
+		}
+		settleSpan.End()
 	}
 
-	// Encode payment header (works for both V1 and V2)
-	paymentHeaders := t.x402Client.EncodePaymentSignatureHeader(payloadBytes)
+	switch {
+	case payments > 0:
+		recordPaymentOutcome(ctx, PaymentOutcomePaid)
+	case t.x402Client.hasPaymentHeader(req.Header):
+		recordPaymentOutcome(ctx, PaymentOutcomeCachedEntitlement)
+	default:
+		recordPaymentOutcome(ctx, PaymentOutcomeFree)
+	}
+
+	return resp, nil
+}
 
-	// Create new request with payment header
-	paymentReq := req.Clone(ctx)
-	for k, v := range paymentHeaders {
-		paymentReq.Header.Set(k, v)
+// hasPaymentHeader reports whether header carries a payment under any known name
+func (c *x402HTTPClient) hasPaymentHeader(header http.Header) bool {
+	for _, name := range []string{c.headerNames.Payment, V2HeaderNames.Payment, V1HeaderNames.Payment} {
+		if name != "" && header.Get(name) != "" {
+			return true
+		}
 	}
+	return false
+}
 
-	// Retry with payment
-	newResp, err := t.Transport.RoundTrip(paymentReq)
-	t.retryCount.Delete(requestID)
+// authorizedRoundTrip sends req with a bearer token from the token provider
+// Authentication is handled before payment: a 401 refreshes the token and
+// retries once, the returned request carries the token for paid retries.
+// Requests whose body cannot be rewound with GetBody are not retried, their
+// 401 is returned as is
+func (t *PaymentRoundTripper) authorizedRoundTrip(ctx context.Context, req *http.Request) (*http.Request, *http.Response, error) {
+	provider := t.x402Client.tokenProvider
+	if provider == nil || req.Header.Get("Authorization") != "" {
+		resp, err := t.Transport.RoundTrip(req)
+		return req, resp, err
+	}
 
-	return newResp, err
+	authorize := func(refresh bool) (*http.Request, error) {
+		token, err := provider(ctx, refresh)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bearer token: %w", err)
+		}
+		authReq := req.Clone(ctx)
+		authReq.Header.Set("Authorization", "Bearer "+token)
+		return authReq, nil
+	}
+
+	authReq, err := authorize(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := t.Transport.RoundTrip(authReq)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return authReq, resp, err
+	}
+
+	// Token rejected, refresh and retry once
+	if req.GetBody == nil && req.Body != nil && req.Body != http.NoBody {
+		return authReq, resp, nil
+	}
+	resp.Body.Close()
+	if authReq, err = authorize(true); err != nil {
+		return nil, nil, err
+	}
+	if req.GetBody != nil {
+		if authReq.Body, err = req.GetBody(); err != nil {
+			return nil, nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+	}
+	resp, err = t.Transport.RoundTrip(authReq)
+	return authReq, resp, err
+}
+
+// firstHeaderValues flattens headers to their first value
+func firstHeaderValues(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for k, v := range header {
+		if len(v) > 0 {
+			headers[k] = v[0]
+		}
+	}
+	return headers
+}
+
+// setRequirementAttributes describes the selected requirements on span
+func setRequirementAttributes(span x402.Span, requirements x402.PaymentRequirementsView) {
+	span.SetAttribute(x402.AttributeScheme, requirements.GetScheme())
+	span.SetAttribute(x402.AttributeNetwork, string(x402.ParseNetwork(requirements.GetNetwork())))
+	span.SetAttribute(x402.AttributeAmount, requirements.GetAmount())
+}
+
+// endSpan ends span, recording err if the step failed
+func endSpan(span x402.Span, err error) {
+	if err != nil && !errors.Is(err, errDeclinePayment) {
+		span.RecordError(err)
+	}
+	span.End()
 }
 
 // handleV1Payment processes V1 PaymentRequired and creates V1 payload
-func (t *PaymentRoundTripper) handleV1Payment(ctx context.Context, body []byte) ([]byte, error) {
+// once the selected requirements are approved
+func (t *PaymentRoundTripper) handleV1Payment(ctx context.Context, body []byte, approve func(x402.PaymentRequirementsView, string) error) ([]byte, error) {
 	// Parse V1 PaymentRequired from body
 	var paymentRequiredV1 types.PaymentRequiredV1
 	if err := json.Unmarshal(body, &paymentRequiredV1); err != nil {
 		return nil, fmt.Errorf("failed to parse V1 payment required: %w", err)
 	}
 
+	// Sandbox clients pay anything without signing
+	if t.x402Client.sandbox {
+		selectedV1, err := selectSandboxRequirements(paymentRequiredV1.Accepts)
+		if err != nil {
+			return nil, err
+		}
+		if selectedV1, err = t.applyFiatPriceV1(ctx, selectedV1); err != nil {
+			return nil, err
+		}
+		if err := approve(selectedV1, selectedV1.Resource); err != nil {
+			return nil, err
+		}
+		return json.Marshal(sandboxPaymentV1(selectedV1))
+	}
+
 	// Select V1 requirements
 	selectedV1, err := t.x402Client.client.SelectPaymentRequirementsV1(paymentRequiredV1.Accepts)
 	if err != nil {
 		return nil, fmt.Errorf("cannot fulfill V1 payment requirements: %w", err)
 	}
+	if selectedV1, err = t.applyFiatPriceV1(ctx, selectedV1); err != nil {
+		return nil, err
+	}
+	if err := approve(selectedV1, selectedV1.Resource); err != nil {
+		return nil, err
+	}
 
 	// Create V1 payment payload
 	payloadV1, err := t.x402Client.client.CreatePaymentPayloadV1(ctx, selectedV1)
@@ -261,7 +764,8 @@ func (t *PaymentRoundTripper) handleV1Payment(ctx context.Context, body []byte)
 }
 
 // handleV2Payment processes V2 PaymentRequired and creates V2 payload
-func (t *PaymentRoundTripper) handleV2Payment(ctx context.Context, headers map[string]string, body []byte) ([]byte, error) {
+// once the selected requirements are approved
+func (t *PaymentRoundTripper) handleV2Payment(ctx context.Context, headers map[string]string, body []byte, approve func(x402.PaymentRequirementsView, string) error) ([]byte, error) {
 	// Parse V2 PaymentRequired (from header or body)
 	var paymentRequiredV2 types.PaymentRequired
 
@@ -287,11 +791,32 @@ func (t *PaymentRoundTripper) handleV2Payment(ctx context.Context, headers map[s
 		return nil, fmt.Errorf("no V2 payment required information found")
 	}
 
+	// Sandbox clients pay anything without signing
+	if t.x402Client.sandbox {
+		selectedV2, err := selectSandboxRequirements(paymentRequiredV2.Accepts)
+		if err != nil {
+			return nil, err
+		}
+		if selectedV2, err = t.applyFiatPrice(ctx, selectedV2); err != nil {
+			return nil, err
+		}
+		if err := approve(selectedV2, resourceURL(paymentRequiredV2.Resource)); err != nil {
+			return nil, err
+		}
+		return json.Marshal(sandboxPayment(selectedV2, paymentRequiredV2))
+	}
+
 	// Select V2 requirements
 	selectedV2, err := t.x402Client.client.SelectPaymentRequirements(paymentRequiredV2.Accepts)
 	if err != nil {
 		return nil, fmt.Errorf("cannot fulfill V2 payment requirements: %w", err)
 	}
+	if selectedV2, err = t.applyFiatPrice(ctx, selectedV2); err != nil {
+		return nil, err
+	}
+	if err := approve(selectedV2, resourceURL(paymentRequiredV2.Resource)); err != nil {
+		return nil, err
+	}
 
 	// Create V2 payment payload
 	payloadV2, err := t.x402Client.client.CreatePaymentPayload(
@@ -308,6 +833,53 @@ func (t *PaymentRoundTripper) handleV2Payment(ctx context.Context, headers map[s
 	return json.Marshal(payloadV2)
 }
 
+// applyFiatPrice replaces the amount of V2 requirements quoted in fiat with
+// its conversion, see WithPriceOracle
+func (t *PaymentRoundTripper) applyFiatPrice(ctx context.Context, requirements types.PaymentRequirements) (types.PaymentRequirements, error) {
+	conversion, err := t.x402Client.convertFiatPrice(ctx, requirements)
+	if err != nil || conversion == nil {
+		return requirements, err
+	}
+	requirements.Amount = conversion.Amount
+	return requirements, nil
+}
+
+// applyFiatPriceV1 replaces the max amount of V1 requirements quoted in fiat
+// with its conversion, see WithPriceOracle
+func (t *PaymentRoundTripper) applyFiatPriceV1(ctx context.Context, requirements types.PaymentRequirementsV1) (types.PaymentRequirementsV1, error) {
+	conversion, err := t.x402Client.convertFiatPrice(ctx, requirements)
+	if err != nil || conversion == nil {
+		return requirements, err
+	}
+	requirements.MaxAmountRequired = conversion.Amount
+	return requirements, nil
+}
+
+// preflightVerify checks a signed payment with the facilitator before it is sent
+func (c *x402HTTPClient) preflightVerify(ctx context.Context, payloadBytes []byte, requirements x402.PaymentRequirementsView) error {
+	requirementsBytes, err := json.Marshal(requirements)
+	if err != nil {
+		return fmt.Errorf("failed to marshal requirements: %w", err)
+	}
+
+	result, err := c.VerifyAuthorization(ctx, SignedAuthorization{
+		Payload:      payloadBytes,
+		Requirements: requirementsBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("payment preflight verification failed: %w", err)
+	}
+	if !result.IsValid {
+		return x402.NewVerifyError(
+			result.InvalidReason,
+			result.Payer,
+			x402.ParseNetwork(requirements.GetNetwork()),
+			errors.New("facilitator rejected payment during preflight"),
+		)
+	}
+	return nil
+}
+
 // detectPaymentRequiredVersion detects protocol version from HTTP response
 func detectPaymentRequiredVersion(headers map[string]string, body []byte) (int, error) {
 	// Normalize headers
@@ -350,7 +922,6 @@ func (c *x402HTTPClient) DoWithPayment(ctx context.Context, req *http.Request) (
 		Transport: &PaymentRoundTripper{
 			Transport:  http.DefaultTransport,
 			x402Client: c,
-			retryCount: &sync.Map{},
 		},
 	}
 
@@ -375,6 +946,174 @@ func (c *x402HTTPClient) PostWithPayment(ctx context.Context, url string, body i
 	return c.DoWithPayment(ctx, req)
 }
 
+// ============================================================================
+// Authorization Verification
+// ============================================================================
+
+// SignedAuthorization is a signed payment payload together with the
+// requirements it pays
+type SignedAuthorization struct {
+	Payload      []byte
+	Requirements []byte
+}
+
+// VerifyAuthorization asks the facilitator configured with WithPreflightVerify
+// whether a signed payment would be accepted, without settling it
+func (c *x402HTTPClient) VerifyAuthorization(ctx context.Context, auth SignedAuthorization) (*x402.VerifyResponse, error) {
+	if c.preflight == nil {
+		return nil, errors.New("no facilitator configured for verification")
+	}
+
+	// Verification is idempotent, retry transient facilitator failures
+	policy := x402.NoRetry
+	if c.client != nil {
+		policy = c.client.RetryPolicy()
+	}
+	var result *x402.VerifyResponse
+	err := policy.Do(ctx, isTransientFacilitatorError, func(ctx context.Context) error {
+		var err error
+		result, err = c.preflight.Verify(ctx, auth.Payload, auth.Requirements)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isTransientFacilitatorError reports whether err is a facilitator failure
+// worth retrying, such as a 502 or 503
+func isTransientFacilitatorError(err error) bool {
+	var statusErr *FacilitatorStatusError
+	return errors.As(err, &statusErr) && statusErr.Temporary()
+}
+
+// ============================================================================
+// On-chain Confirmation
+// ============================================================================
+
+// defaultConfirmPollInterval is how often ConfirmOnChain polls the chain
+const defaultConfirmPollInterval = 2 * time.Second
+
+// ConfirmOnChain polls the chain client configured with WithOnChainConfirmation
+// until txHash has at least the given number of confirmations or ctx expires
+func (c *x402HTTPClient) ConfirmOnChain(ctx context.Context, txHash string, confirmations uint64) error {
+	if c.chain == nil {
+		return errors.New("no chain client configured for on-chain confirmation")
+	}
+	if confirmations == 0 {
+		confirmations = 1
+	}
+
+	ticker := time.NewTicker(c.confirmPoll)
+	defer ticker.Stop()
+
+	for {
+		confirmed, err := c.chain.TransactionConfirmations(ctx, txHash)
+		if err != nil {
+			return fmt.Errorf("failed to read confirmations for %s: %w", txHash, err)
+		}
+		if confirmed >= confirmations {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("transaction %s has %d of %d confirmations: %w", txHash, confirmed, confirmations, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ============================================================================
+// Payment Outcome
+// ============================================================================
+
+// PaymentOutcome describes whether and how a response was paid for
+type PaymentOutcome string
+
+const (
+	// PaymentOutcomeFree means the resource was served without a payment challenge
+	PaymentOutcomeFree PaymentOutcome = "free"
+	// PaymentOutcomePaid means the client paid at least one challenge
+	PaymentOutcomePaid PaymentOutcome = "paid"
+	// PaymentOutcomeCachedEntitlement means a payment header already on the request was accepted
+	PaymentOutcomeCachedEntitlement PaymentOutcome = "cached-entitlement"
+	// PaymentOutcomeDeclined means the client did not pay (or pay again) and the 402 is returned
+	PaymentOutcomeDeclined PaymentOutcome = "declined"
+)
+
+// FetchResult is the response of a Fetch along with how it was paid for
+type FetchResult struct {
+	Response   *http.Response
+	Outcome    PaymentOutcome
+	Settlement *x402.SettleResponse // Decoded payment response header, if any
+	Confirmed  bool                 // Settlement confirmed on chain, see WithOnChainConfirmation
+
+	// Requirements of the last challenge answered, paid or declined
+	Requirements *PaymentRequirements
+
+	// FiatConversion of a fiat price paid, see WithPriceOracle
+	FiatConversion *FiatConversion
+}
+
+// Fetch performs an HTTP request with automatic payment handling and reports
+// whether a payment actually happened. When the paid challenge advertised a
+// content hash, the response body is verified against it, see ExtraContentHash
+func (c *x402HTTPClient) Fetch(ctx context.Context, req *http.Request) (*FetchResult, error) {
+	return c.fetch(ctx, req, &fetchState{})
+}
+
+// fetch implements Fetch, collecting what happened into state
+func (c *x402HTTPClient) fetch(ctx context.Context, req *http.Request, state *fetchState) (*FetchResult, error) {
+	ctx = context.WithValue(ctx, fetchStateKey{}, state)
+
+	resp, err := c.DoWithPayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FetchResult{
+		Response:     resp,
+		Outcome:      state.outcome,
+		Requirements: state.requirements,
+
+		FiatConversion: state.fiat,
+	}
+
+	headers := make(map[string]string)
+	for k, v := range resp.Header {
+		if len(v) > 0 {
+			headers[k] = v[0]
+		}
+	}
+	if settlement, err := c.GetPaymentSettleResponse(headers); err == nil {
+		result.Settlement = settlement
+	}
+
+	// Independently confirm the settlement when opted in
+	if c.chain != nil && !c.sandbox && result.Outcome == PaymentOutcomePaid && result.Settlement != nil && result.Settlement.Transaction != "" {
+		if err := c.ConfirmOnChain(ctx, result.Settlement.Transaction, c.confirmations); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		result.Confirmed = true
+	}
+
+	// Make sure the content paid for is the content delivered
+	if result.Outcome == PaymentOutcomePaid && resp.StatusCode < http.StatusMultipleChoices {
+		if err := verifyContentHash(resp, result.Requirements); err != nil {
+			resp.Body.Close()
+			if result.Settlement != nil {
+				err = fmt.Errorf("%w (paid in transaction %s)", err, result.Settlement.Transaction)
+			}
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
 // ============================================================================
 // Header Encoding/Decoding Functions
 // ============================================================================
@@ -414,14 +1153,15 @@ func encodePaymentResponseHeader(response x402.SettleResponse) string {
 
 // decodePaymentResponseHeader decodes a base64 payment response header
 func decodePaymentResponseHeader(header string) (*x402.SettleResponse, error) {
-	data, err := base64.StdEncoding.DecodeString(header)
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(header))
 	if err != nil {
-		return nil, fmt.Errorf("invalid base64 encoding: %w", err)
+		return nil, fmt.Errorf("%w: invalid base64 encoding: %w", ErrInvalidPaymentResponse, err)
 	}
 
+	// A partially decoded response is never returned
 	var response x402.SettleResponse
 	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, fmt.Errorf("invalid settle response JSON: %w", err)
+		return nil, fmt.Errorf("%w: invalid settle response JSON: %w", ErrInvalidPaymentResponse, err)
 	}
 
 	return &response, nil
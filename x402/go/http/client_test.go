@@ -12,13 +12,21 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	x402 "github.com/coinbase/x402/go"
 	"github.com/coinbase/x402/go/types"
@@ -222,6 +230,60 @@ func TestGetPaymentSettleResponse(t *testing.T) {
 	}
 }
 
+func TestParseSettleResponse(t *testing.T) {
+	encode := func(v string) string {
+		return base64.StdEncoding.EncodeToString([]byte(v))
+	}
+	v2 := encode(`{"success":true,"transaction":"0xv2","network":"eip155:1","payer":"0xpayer"}`)
+	v1 := encode(`{"success":true,"transaction":"0xv1","network":"base-sepolia","payer":"0xpayer"}`)
+
+	// Both headers present, v2 wins
+	header := http.Header{}
+	header.Set("PAYMENT-RESPONSE", v2)
+	header.Set("X-PAYMENT-RESPONSE", v1)
+	result, err := ParseSettleResponse(header)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Transaction != "0xv2" {
+		t.Errorf("Expected v2 transaction, got %s", result.Transaction)
+	}
+
+	// v1 only
+	header = http.Header{}
+	header.Set("X-PAYMENT-RESPONSE", v1)
+	result, err = ParseSettleResponse(header)
+	if err != nil {
+		t.Fatalf("Unexpected error for v1: %v", err)
+	}
+	if result.Transaction != "0xv1" || string(result.Network) != "base-sepolia" {
+		t.Errorf("Unexpected v1 response: %+v", result)
+	}
+
+	// Malformed base64
+	header = http.Header{}
+	header.Set("PAYMENT-RESPONSE", "not-base64!")
+	if _, err := ParseSettleResponse(header); !errors.Is(err, ErrInvalidPaymentResponse) {
+		t.Errorf("Expected ErrInvalidPaymentResponse, got %v", err)
+	}
+
+	// Partially valid JSON must not yield a partial response
+	header = http.Header{}
+	header.Set("PAYMENT-RESPONSE", encode(`{"success":true,"transaction":123}`))
+	result, err = ParseSettleResponse(header)
+	if !errors.Is(err, ErrInvalidPaymentResponse) {
+		t.Errorf("Expected ErrInvalidPaymentResponse, got %v", err)
+	}
+	if result != nil {
+		t.Errorf("Expected no response, got %+v", result)
+	}
+
+	// No header
+	if _, err := ParseSettleResponse(http.Header{}); !errors.Is(err, ErrNoPaymentResponse) {
+		t.Errorf("Expected ErrNoPaymentResponse, got %v", err)
+	}
+}
+
 func TestPaymentRoundTripper(t *testing.T) {
 	// Create a test server that returns 402 first, then 200
 	callCount := 0
@@ -296,6 +358,162 @@ func TestPaymentRoundTripper(t *testing.T) {
 	}
 }
 
+func TestPaymentRoundTripperHeaderNegotiation(t *testing.T) {
+	t.Run("v1 challenge sends X-PAYMENT", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+				t.Error("Expected no PAYMENT-SIGNATURE header for a v1 server")
+			}
+			if r.Header.Get("X-PAYMENT") == "" {
+				w.WriteHeader(http.StatusPaymentRequired)
+				_ = json.NewEncoder(w).Encode(types.PaymentRequiredV1{
+					X402Version: 1,
+					Accepts: []types.PaymentRequirementsV1{
+						{Scheme: "exact", Network: "base", MaxAmountRequired: "1000", Resource: "/paid", PayTo: "0xmerchant", Asset: "0xusdc"},
+					},
+				})
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		result, err := NewSandboxClient().Fetch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer result.Response.Body.Close()
+
+		if result.Response.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", result.Response.StatusCode)
+		}
+		if result.Settlement == nil || result.Response.Header.Get("X-PAYMENT-RESPONSE") == "" {
+			t.Errorf("Expected a settlement in X-PAYMENT-RESPONSE, got %+v", result.Settlement)
+		}
+	})
+
+	t.Run("override", func(t *testing.T) {
+		settled := encodePaymentResponseHeader(x402.SettleResponse{Success: true, Transaction: "0xabc", Network: "eip155:8453"})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("PAYMENT") == "" {
+				requirements := x402.PaymentRequired{
+					X402Version: 2,
+					Accepts: []x402.PaymentRequirements{
+						{Scheme: "exact", Network: "eip155:8453", Asset: "0xusdc", Amount: "1000", PayTo: "0xmerchant"},
+					},
+				}
+				reqJSON, _ := json.Marshal(requirements)
+				w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+				w.WriteHeader(http.StatusPaymentRequired)
+				return
+			}
+			w.Header().Set("X-Settlement", settled)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := NewSandboxClient(WithPaymentHeaderNames(PaymentHeaderNames{Payment: "PAYMENT", PaymentResponse: "X-Settlement"}))
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		result, err := client.Fetch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer result.Response.Body.Close()
+
+		if result.Response.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", result.Response.StatusCode)
+		}
+		if result.Settlement == nil || result.Settlement.Transaction != "0xabc" {
+			t.Errorf("Expected the settlement from X-Settlement, got %+v", result.Settlement)
+		}
+	})
+}
+
+func TestPaymentRoundTripperRechallengeTopUp(t *testing.T) {
+	// Server re-challenges the first payment at a higher price, then accepts
+	prices := []string{"1000", "1500"}
+	callCount := 0
+	paidCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			paidCount++
+		}
+
+		if callCount <= len(prices) {
+			requirements := x402.PaymentRequired{
+				X402Version: 2,
+				Accepts: []x402.PaymentRequirements{
+					{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: prices[callCount-1], PayTo: "0xtest"},
+				},
+			}
+			reqJSON, _ := json.Marshal(requirements)
+			w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+			w.WriteHeader(http.StatusPaymentRequired)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("Success"))
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+
+	t.Run("pays both challenges within the cap", func(t *testing.T) {
+		callCount, paidCount = 0, 0
+		client := Newx402HTTPClient(x402Client, WithMaxRechallenges(2), WithSpendLimit(big.NewInt(2500)))
+		httpClient := WrapHTTPClientWithPayment(&http.Client{}, client)
+
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+		if paidCount != 2 {
+			t.Errorf("Expected 2 paid requests, got %d", paidCount)
+		}
+	})
+
+	t.Run("stops when the top-up exceeds the cap", func(t *testing.T) {
+		callCount, paidCount = 0, 0
+		client := Newx402HTTPClient(x402Client, WithMaxRechallenges(2), WithSpendLimit(big.NewInt(2000)))
+		httpClient := WrapHTTPClientWithPayment(&http.Client{}, client)
+
+		_, err := httpClient.Get(server.URL)
+		if !errors.Is(err, ErrSpendLimitExceeded) {
+			t.Fatalf("Expected ErrSpendLimitExceeded, got %v", err)
+		}
+		if paidCount != 1 {
+			t.Errorf("Expected 1 paid request, got %d", paidCount)
+		}
+	})
+
+	t.Run("returns the re-challenge when disabled", func(t *testing.T) {
+		callCount, paidCount = 0, 0
+		httpClient := WrapHTTPClientWithPayment(&http.Client{}, Newx402HTTPClient(x402Client))
+
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusPaymentRequired {
+			t.Errorf("Expected status 402, got %d", resp.StatusCode)
+		}
+		if paidCount != 1 {
+			t.Errorf("Expected 1 paid request, got %d", paidCount)
+		}
+	})
+}
+
 func TestPaymentRoundTripperNoRetryOn200(t *testing.T) {
 	// Server that always returns 200
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -320,84 +538,1322 @@ func TestPaymentRoundTripperNoRetryOn200(t *testing.T) {
 	}
 }
 
-func TestDoWithPayment(t *testing.T) {
+func TestPaymentRoundTripperMaxPrice(t *testing.T) {
+	paidCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("Success"))
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			paidCount++
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		price := "100"
+		if r.URL.Path == "/expensive" {
+			price = "50000"
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{
+				{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: price, PayTo: "0xtest"},
+			},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
 	}))
 	defer server.Close()
 
-	client := Newx402HTTPClient(x402.Newx402Client())
-	ctx := context.Background()
-	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	httpClient := WrapHTTPClientWithPayment(&http.Client{}, Newx402HTTPClient(x402Client))
+	ctx := WithRequestOptions(context.Background(), RequestOptions{MaxPrice: big.NewInt(1000)})
 
-	resp, err := client.DoWithPayment(ctx, req)
+	// Cheap resource is paid automatically
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/cheap", nil)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
-	defer resp.Body.Close()
-
+	resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		t.Errorf("Expected status 200, got %d", resp.StatusCode)
 	}
+
+	// Expensive resource is refused
+	req, _ = http.NewRequestWithContext(ctx, "GET", server.URL+"/expensive", nil)
+	_, err = httpClient.Do(req)
+	if !errors.Is(err, ErrPriceAboveMax) {
+		t.Fatalf("Expected ErrPriceAboveMax, got %v", err)
+	}
+
+	// With optional payment the 402 is returned instead
+	optionalCtx := WithRequestOptions(context.Background(), RequestOptions{MaxPrice: big.NewInt(1000), PaymentOptional: true})
+	req, _ = http.NewRequestWithContext(optionalCtx, "GET", server.URL+"/expensive", nil)
+	resp, err = httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPaymentRequired {
+		t.Errorf("Expected status 402, got %d", resp.StatusCode)
+	}
+
+	if paidCount != 1 {
+		t.Errorf("Expected 1 paid request, got %d", paidCount)
+	}
 }
 
-func TestGetWithPayment(t *testing.T) {
+func TestVerifyAuthorization(t *testing.T) {
+	valid := true
+	facilitator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/verify" {
+			t.Errorf("Expected /verify, got %s", r.URL.Path)
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["paymentRequirements"] == nil {
+			t.Error("Expected paymentRequirements in verify request")
+		}
+
+		if valid {
+			_ = json.NewEncoder(w).Encode(x402.VerifyResponse{IsValid: true, Payer: "0xpayer"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(x402.VerifyResponse{IsValid: false, InvalidReason: "invalid_signature"})
+	}))
+	defer facilitator.Close()
+
+	paidCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != "GET" {
-			t.Errorf("Expected GET, got %s", r.Method)
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			paidCount++
+			w.WriteHeader(http.StatusOK)
+			return
 		}
-		w.WriteHeader(http.StatusOK)
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{
+				{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest"},
+			},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
 	}))
 	defer server.Close()
 
-	client := Newx402HTTPClient(x402.Newx402Client())
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	client := Newx402HTTPClient(x402Client, WithPreflightVerify(NewHTTPFacilitatorClient(&FacilitatorConfig{URL: facilitator.URL})))
 	ctx := context.Background()
 
+	payload, _ := json.Marshal(x402.PaymentPayload{X402Version: 2, Payload: map[string]interface{}{"mock": "payload"}})
+	requirements, _ := json.Marshal(x402.PaymentRequirements{Scheme: "mock", Network: "test:1", Amount: "1000"})
+
+	result, err := client.VerifyAuthorization(ctx, SignedAuthorization{Payload: payload, Requirements: requirements})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsValid || result.Payer != "0xpayer" {
+		t.Errorf("Expected valid result for 0xpayer, got %+v", result)
+	}
+
 	resp, err := client.GetWithPayment(ctx, server.URL)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
-	defer resp.Body.Close()
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || paidCount != 1 {
+		t.Errorf("Expected paid 200, got status %d with %d payments", resp.StatusCode, paidCount)
+	}
+
+	// Invalid authorizations are caught before paying
+	valid = false
+	result, err = client.VerifyAuthorization(ctx, SignedAuthorization{Payload: payload, Requirements: requirements})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsValid || result.InvalidReason != "invalid_signature" {
+		t.Errorf("Expected invalid_signature, got %+v", result)
+	}
+
+	_, err = client.GetWithPayment(ctx, server.URL)
+	var verifyErr *x402.VerifyError
+	if !errors.As(err, &verifyErr) || verifyErr.Reason != "invalid_signature" {
+		t.Fatalf("Expected VerifyError with invalid_signature, got %v", err)
+	}
+	if paidCount != 1 {
+		t.Errorf("Expected rejected payment not to be sent, got %d payments", paidCount)
+	}
 }
 
-func TestPostWithPayment(t *testing.T) {
+func TestPreflightVerifyRetry(t *testing.T) {
+	var failures, verifyCalls int
+	facilitator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyCalls++
+		if verifyCalls <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("upstream unavailable"))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(x402.VerifyResponse{IsValid: true, Payer: "0xpayer"})
+	}))
+	defer facilitator.Close()
+
+	paidCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != "POST" {
-			t.Errorf("Expected POST, got %s", r.Method)
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			paidCount++
+			w.WriteHeader(http.StatusOK)
+			return
 		}
-		body, _ := io.ReadAll(r.Body)
-		if string(body) != "test body" {
-			t.Errorf("Expected 'test body', got %s", string(body))
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{
+				{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest"},
+			},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client(x402.WithRetryPolicy(x402.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	client := Newx402HTTPClient(x402Client, WithPreflightVerify(NewHTTPFacilitatorClient(&FacilitatorConfig{URL: facilitator.URL})))
+	ctx := context.Background()
+
+	// Two 503s are retried and the payment goes through
+	failures = 2
+	resp, err := client.GetWithPayment(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if verifyCalls != 3 || paidCount != 1 {
+		t.Errorf("Expected 3 verify calls and 1 payment, got %d and %d", verifyCalls, paidCount)
+	}
+
+	// A persistent outage gives up after 3 attempts without paying
+	failures, verifyCalls, paidCount = 10, 0, 0
+	_, err = client.GetWithPayment(ctx, server.URL)
+	var retryErr *x402.RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+		t.Fatalf("Expected RetryError after 3 attempts, got %v", err)
+	}
+	var statusErr *FacilitatorStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the facilitator 503 to be wrapped, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("Expected the attempts in the error message, got %q", err.Error())
+	}
+	if verifyCalls != 3 || paidCount != 0 {
+		t.Errorf("Expected 3 verify calls and no payment, got %d and %d", verifyCalls, paidCount)
+	}
+
+	// Retries can be disabled
+	client = Newx402HTTPClient(x402.Newx402Client(x402.WithRetryPolicy(x402.NoRetry)).Register("test:1", &mockSchemeClient{scheme: "mock"}),
+		WithPreflightVerify(NewHTTPFacilitatorClient(&FacilitatorConfig{URL: facilitator.URL})))
+	failures, verifyCalls = 10, 0
+	_, err = client.GetWithPayment(ctx, server.URL)
+	if err == nil || errors.As(err, &retryErr) || verifyCalls != 1 {
+		t.Errorf("Expected a single failed attempt, got %d calls and %v", verifyCalls, err)
+	}
+}
+func TestFetchPaymentOutcome(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/paid" && r.Header.Get("PAYMENT-SIGNATURE") == "" {
+			requirements := x402.PaymentRequired{
+				X402Version: 2,
+				Accepts: []x402.PaymentRequirements{
+					{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest"},
+				},
+			}
+			reqJSON, _ := json.Marshal(requirements)
+			w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+			w.WriteHeader(http.StatusPaymentRequired)
+			return
+		}
+		if r.URL.Path == "/paid" {
+			w.Header().Set("PAYMENT-RESPONSE", encodePaymentResponseHeader(x402.SettleResponse{
+				Success:     true,
+				Transaction: "0xtx",
+			}))
 		}
 		w.WriteHeader(http.StatusOK)
 	}))
 	defer server.Close()
 
-	client := Newx402HTTPClient(x402.Newx402Client())
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	client := Newx402HTTPClient(x402Client)
 	ctx := context.Background()
 
-	resp, err := client.PostWithPayment(ctx, server.URL, strings.NewReader("test body"))
+	tests := []struct {
+		name    string
+		path    string
+		header  string
+		outcome PaymentOutcome
+	}{
+		{"free", "/free", "", PaymentOutcomeFree},
+		{"paid", "/paid", "", PaymentOutcomePaid},
+		{"cached entitlement", "/paid", "cached", PaymentOutcomeCachedEntitlement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("PAYMENT-SIGNATURE", tt.header)
+			}
+
+			result, err := client.Fetch(ctx, req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer result.Response.Body.Close()
+
+			if result.Response.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", result.Response.StatusCode)
+			}
+			if result.Outcome != tt.outcome {
+				t.Errorf("Expected outcome %s, got %s", tt.outcome, result.Outcome)
+			}
+			if tt.outcome == PaymentOutcomePaid && (result.Settlement == nil || result.Settlement.Transaction != "0xtx") {
+				t.Errorf("Expected settlement with transaction 0xtx, got %+v", result.Settlement)
+			}
+		})
+	}
+
+	// Opt-in on-chain confirmation after settlement
+	chain := &mockChainClient{}
+	confirming := Newx402HTTPClient(x402Client, WithOnChainConfirmation(chain, 2))
+	confirming.confirmPoll = time.Millisecond
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/paid", nil)
+	result, err := confirming.Fetch(ctx, req)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
-	defer resp.Body.Close()
+	defer result.Response.Body.Close()
+
+	if !result.Confirmed {
+		t.Error("Expected settlement to be confirmed on chain")
+	}
+	if chain.polls != 3 {
+		t.Errorf("Expected 3 polls to reach 2 confirmations, got %d", chain.polls)
+	}
 }
 
-// Mock scheme client for testing
-type mockSchemeClient struct {
-	scheme string
+// mockChainClient reports one more confirmation on every poll
+type mockChainClient struct {
+	polls int
 }
 
-func (m *mockSchemeClient) Scheme() string {
-	return m.scheme
+func (m *mockChainClient) TransactionConfirmations(ctx context.Context, txHash string) (uint64, error) {
+	m.polls++
+	return uint64(m.polls - 1), nil
 }
 
-func (m *mockSchemeClient) CreatePaymentPayload(ctx context.Context, requirements types.PaymentRequirements) (types.PaymentPayload, error) {
-	return types.PaymentPayload{
-		X402Version: 2,
-		Payload:     map[string]interface{}{"mock": "payload"},
-	}, nil
+func TestConfirmOnChain(t *testing.T) {
+	chain := &mockChainClient{}
+	client := Newx402HTTPClient(x402.Newx402Client(), WithOnChainConfirmation(chain, 3))
+	client.confirmPoll = time.Millisecond
+
+	if err := client.ConfirmOnChain(context.Background(), "0xtx", 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if chain.polls != 4 {
+		t.Errorf("Expected 4 polls to reach 3 confirmations, got %d", chain.polls)
+	}
+
+	// Context expiry before reaching the confirmations
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := client.ConfirmOnChain(ctx, "0xtx", 1000000)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	// Not configured
+	if err := Newx402HTTPClient(x402.Newx402Client()).ConfirmOnChain(context.Background(), "0xtx", 1); err == nil {
+		t.Error("Expected error without a chain client")
+	}
+}
+
+// capturingTracer records every span it starts
+type capturingTracer struct {
+	spans []*capturedSpan
+}
+
+type capturedSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (c *capturingTracer) Start(ctx context.Context, name string) (context.Context, x402.Span) {
+	span := &capturedSpan{name: name, attrs: map[string]interface{}{}}
+	c.spans = append(c.spans, span)
+	return ctx, span
+}
+
+func (s *capturedSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *capturedSpan) RecordError(err error)                      { s.err = err }
+func (s *capturedSpan) End()                                       { s.ended = true }
+
+func TestPaymentRoundTripperTracing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PAYMENT-SIGNATURE") == "" {
+			requirements := x402.PaymentRequired{
+				X402Version: 2,
+				Accepts: []x402.PaymentRequirements{
+					{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest"},
+				},
+			}
+			reqJSON, _ := json.Marshal(requirements)
+			w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+			w.WriteHeader(http.StatusPaymentRequired)
+			return
+		}
+		w.Header().Set("PAYMENT-RESPONSE", encodePaymentResponseHeader(x402.SettleResponse{
+			Success:     true,
+			Transaction: "0xtx",
+		}))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	tracer := &capturingTracer{}
+	client := WrapHTTPClientWithPayment(&http.Client{}, Newx402HTTPClient(x402Client, WithTracer(tracer)))
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	expected := []string{x402.SpanParseChallenge, x402.SpanSign, x402.SpanSettle}
+	if len(tracer.spans) != len(expected) {
+		t.Fatalf("Expected %d spans, got %d", len(expected), len(tracer.spans))
+	}
+	for i, span := range tracer.spans {
+		if span.name != expected[i] {
+			t.Errorf("Span %d: expected %s, got %s", i, expected[i], span.name)
+		}
+		if !span.ended {
+			t.Errorf("Span %s was not ended", span.name)
+		}
+		if span.err != nil {
+			t.Errorf("Span %s recorded error: %v", span.name, span.err)
+		}
+		if span.attrs[x402.AttributeScheme] != "mock" || span.attrs[x402.AttributeNetwork] != "test:1" || span.attrs[x402.AttributeAmount] != "1000" {
+			t.Errorf("Span %s has unexpected attributes: %v", span.name, span.attrs)
+		}
+	}
+	if tracer.spans[0].attrs[x402.AttributeVersion] != 2 {
+		t.Errorf("Expected version attribute 2, got %v", tracer.spans[0].attrs[x402.AttributeVersion])
+	}
+	if tracer.spans[2].attrs[x402.AttributeTxHash] != "0xtx" {
+		t.Errorf("Expected tx hash attribute 0xtx, got %v", tracer.spans[2].attrs[x402.AttributeTxHash])
+	}
+}
+
+func TestPaymentRoundTripperBearerToken(t *testing.T) {
+	var paidAuth, paidSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("PAYMENT-SIGNATURE") == "" {
+			requirements := x402.PaymentRequired{
+				X402Version: 2,
+				Accepts: []x402.PaymentRequirements{
+					{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest"},
+				},
+			}
+			reqJSON, _ := json.Marshal(requirements)
+			w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+			w.WriteHeader(http.StatusPaymentRequired)
+			return
+		}
+		paidAuth = r.Header.Get("Authorization")
+		paidSignature = r.Header.Get("PAYMENT-SIGNATURE")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var refreshes int
+	provider := func(ctx context.Context, refresh bool) (string, error) {
+		if refresh {
+			refreshes++
+			return "fresh", nil
+		}
+		return "stale", nil
+	}
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	client := WrapHTTPClientWithPayment(&http.Client{}, Newx402HTTPClient(x402Client, WithTokenProvider(provider)))
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if refreshes != 1 {
+		t.Errorf("Expected 1 token refresh, got %d", refreshes)
+	}
+	if paidAuth != "Bearer fresh" {
+		t.Errorf("Expected bearer token on paid retry, got %q", paidAuth)
+	}
+	if paidSignature == "" {
+		t.Error("Expected payment header on paid retry")
+	}
+}
+
+func TestPaymentRoundTripperBearerTokenBody(t *testing.T) {
+	var freshBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		freshBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	provider := func(ctx context.Context, refresh bool) (string, error) {
+		if refresh {
+			return "fresh", nil
+		}
+		return "stale", nil
+	}
+	client := WrapHTTPClientWithPayment(&http.Client{}, Newx402HTTPClient(x402.Newx402Client(), WithTokenProvider(provider)))
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if freshBody != "payload" {
+		t.Errorf("Expected body on the retried request, got %q", freshBody)
+	}
+
+	// A body that cannot be rewound is not retried
+	freshBody = ""
+	req, _ := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader("payload")))
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", resp.StatusCode)
+	}
+	if freshBody != "" {
+		t.Errorf("Expected no retry, got body %q", freshBody)
+	}
+}
+
+func TestSandboxClientFetch(t *testing.T) {
+	var paid types.PaymentPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature := r.Header.Get("PAYMENT-SIGNATURE")
+		if signature == "" {
+			requirements := x402.PaymentRequired{
+				X402Version: 2,
+				Accepts: []x402.PaymentRequirements{
+					{Scheme: "exact", Network: "eip155:8453", Asset: "0xusdc", Amount: "1000", PayTo: "0xmerchant"},
+				},
+			}
+			reqJSON, _ := json.Marshal(requirements)
+			w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+			w.WriteHeader(http.StatusPaymentRequired)
+			return
+		}
+		data, _ := base64.StdEncoding.DecodeString(signature)
+		if err := json.Unmarshal(data, &paid); err != nil {
+			t.Errorf("Invalid payment payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// No scheme is registered, the sandbox pays any challenge
+	client := NewSandboxClient()
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	result, err := client.Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer result.Response.Body.Close()
+
+	if result.Response.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", result.Response.StatusCode)
+	}
+	if result.Outcome != PaymentOutcomePaid {
+		t.Errorf("Expected outcome %s, got %s", PaymentOutcomePaid, result.Outcome)
+	}
+	if paid.Accepted.Scheme != "exact" || paid.Payload["sandbox"] != true {
+		t.Errorf("Expected a sandbox payload for the exact scheme, got %+v", paid)
+	}
+	if result.Settlement == nil || !result.Settlement.Success || result.Settlement.Network != "eip155:8453" {
+		t.Fatalf("Expected a successful sandbox settlement, got %+v", result.Settlement)
+	}
+	if !IsSandboxTransaction(result.Settlement.Transaction) {
+		t.Errorf("Expected a sandbox transaction hash, got %s", result.Settlement.Transaction)
+	}
+
+	// The fake transaction hash is deterministic
+	req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	again, err := client.Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	again.Response.Body.Close()
+	if again.Settlement == nil || again.Settlement.Transaction != result.Settlement.Transaction {
+		t.Errorf("Expected transaction %s, got %+v", result.Settlement.Transaction, again.Settlement)
+	}
+	if IsSandboxTransaction("0x" + strings.Repeat("ab", 32)) {
+		t.Error("Expected a real looking hash not to be a sandbox transaction")
+	}
+}
+
+func TestPaymentRequirementsJSON(t *testing.T) {
+	challenge := `{
+		"scheme": "exact",
+		"network": "eip155:8453",
+		"asset": "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913",
+		"amount": "10000",
+		"payTo": "0x209693Bc6afc0C5328bA36FaF03C514EF312287C",
+		"resource": "https://api.example.com/premium-data",
+		"maxTimeoutSeconds": 60,
+		"extra": {"name": "USD Coin", "version": "2"}
+	}`
+
+	var requirements PaymentRequirements
+	if err := json.Unmarshal([]byte(challenge), &requirements); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if requirements.Amount != "10000" || requirements.Resource != "https://api.example.com/premium-data" || requirements.Extra["name"] != "USD Coin" {
+		t.Errorf("Unexpected requirements: %+v", requirements)
+	}
+
+	data, err := json.Marshal(requirements)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var want, got map[string]interface{}
+	_ = json.Unmarshal([]byte(challenge), &want)
+	_ = json.Unmarshal(data, &got)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Both protocol versions decode to the same shape
+	extra := json.RawMessage(`{"name": "USD Coin", "version": "2"}`)
+	v1 := newPaymentRequirements(types.PaymentRequirementsV1{
+		Scheme:            "exact",
+		Network:           "eip155:8453",
+		MaxAmountRequired: "10000",
+		Resource:          "https://api.example.com/premium-data",
+		PayTo:             "0x209693Bc6afc0C5328bA36FaF03C514EF312287C",
+		MaxTimeoutSeconds: 60,
+		Asset:             "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913",
+		Extra:             &extra,
+	}, "https://api.example.com/premium-data")
+	if !reflect.DeepEqual(*v1, requirements) {
+		t.Errorf("Expected %+v, got %+v", requirements, *v1)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	paid := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			paid = true
+		}
+		if r.URL.Path == "/free" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Resource:    &x402.ResourceInfo{URL: "https://example.com/paid"},
+			Accepts: []x402.PaymentRequirements{
+				{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest", MaxTimeoutSeconds: 30},
+			},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	client := Newx402HTTPClient(x402Client)
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/paid", nil)
+	quote, err := client.Quote(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := &PaymentRequirements{
+		Scheme:            "mock",
+		Network:           "test:1",
+		Asset:             "TEST",
+		Amount:            "1000",
+		PayTo:             "0xtest",
+		Resource:          "https://example.com/paid",
+		MaxTimeoutSeconds: 30,
+	}
+	if quote == nil || quote.QuotedAt.IsZero() {
+		t.Fatalf("Expected a timestamped quote, got %+v", quote)
+	}
+	quote.QuotedAt = time.Time{}
+	if !reflect.DeepEqual(quote, want) {
+		t.Errorf("Expected %+v, got %+v", want, quote)
+	}
+	if paid {
+		t.Error("Quote must not pay")
+	}
+
+	req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL+"/free", nil)
+	quote, err = client.Quote(context.Background(), req)
+	if err != nil || quote != nil {
+		t.Errorf("Expected no quote for a free resource, got %+v, %v", quote, err)
+	}
+
+	// Fetch reports the requirements it paid
+	req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL+"/paid", nil)
+	result, err := client.Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result.Response.Body.Close()
+	if !paid || !reflect.DeepEqual(result.Requirements, want) {
+		t.Errorf("Expected paid requirements %+v, got %+v", want, result.Requirements)
+	}
+}
+
+func TestQuoteMaxStaleness(t *testing.T) {
+	var amount atomic.Value
+	amount.Store("1000")
+	paid := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			paid++
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{
+				{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: amount.Load().(string), PayTo: "0xtest", MaxTimeoutSeconds: 30},
+			},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+
+	fetch := func(client *x402HTTPClient, quoted, charged string) error {
+		amount.Store(quoted)
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		if _, err := client.Quote(context.Background(), req); err != nil {
+			t.Fatalf("Unexpected quote error: %v", err)
+		}
+		amount.Store(charged)
+		req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		result, err := client.Fetch(context.Background(), req)
+		if err == nil {
+			result.Response.Body.Close()
+		}
+		return err
+	}
+
+	// Every quote is stale by the time it is paid
+	stale := Newx402HTTPClient(x402Client, WithQuoteMaxStaleness(time.Nanosecond), WithPriceTolerance(0.05))
+
+	if err := fetch(stale, "1000", "1040"); err != nil {
+		t.Fatalf("Expected payment within tolerance, got %v", err)
+	}
+	if paid != 1 {
+		t.Fatalf("Expected 1 payment, got %d", paid)
+	}
+
+	err := fetch(stale, "1000", "1200")
+	if !errors.Is(err, ErrQuotePriceMoved) {
+		t.Fatalf("Expected ErrQuotePriceMoved, got %v", err)
+	}
+	if paid != 1 {
+		t.Errorf("Expected no payment after the price moved, got %d payments", paid)
+	}
+
+	// A fresh quote is not re-checked
+	fresh := Newx402HTTPClient(x402Client, WithQuoteMaxStaleness(time.Hour), WithPriceTolerance(0.05))
+	if err := fetch(fresh, "1000", "1200"); err != nil {
+		t.Fatalf("Expected payment of a fresh quote, got %v", err)
+	}
+	if paid != 2 {
+		t.Errorf("Expected 2 payments, got %d", paid)
+	}
+}
+
+func TestFetchFromCatalog(t *testing.T) {
+	var paid atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PAYMENT-SIGNATURE") == "" {
+			requirements := x402.PaymentRequired{
+				X402Version: 2,
+				Accepts: []x402.PaymentRequirements{
+					{Scheme: "exact", Network: "eip155:8453", Asset: "0xusdc", Amount: "1000", PayTo: "0xmerchant"},
+				},
+			}
+			reqJSON, _ := json.Marshal(requirements)
+			w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+			w.WriteHeader(http.StatusPaymentRequired)
+			return
+		}
+		paid.Add(1)
+		_, _ = w.Write([]byte("report"))
+	}))
+	defer server.Close()
+
+	catalog := &Catalog{Resources: []CatalogResource{
+		{ID: "report", URL: server.URL + "/report", Network: "eip155:8453", Amount: "1000"},
+		{ID: "cheap", URL: server.URL + "/cheap", Amount: "500"},
+		{ID: "other-asset", URL: server.URL + "/report", Asset: "0xdai", Amount: "1000"},
+	}}
+	client := NewSandboxClient()
+
+	result, err := client.FetchFromCatalog(context.Background(), catalog, "report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer result.Response.Body.Close()
+	if result.Outcome != PaymentOutcomePaid || result.Response.StatusCode != http.StatusOK {
+		t.Fatalf("Expected a paid 200, got %s %d", result.Outcome, result.Response.StatusCode)
+	}
+
+	for _, id := range []string{"cheap", "other-asset"} {
+		if _, err := client.FetchFromCatalog(context.Background(), catalog, id); !errors.Is(err, ErrCatalogPriceMismatch) {
+			t.Errorf("Expected ErrCatalogPriceMismatch for %s, got %v", id, err)
+		}
+	}
+	if paid.Load() != 1 {
+		t.Errorf("Expected a single payment, got %d", paid.Load())
+	}
+
+	if _, err := client.FetchFromCatalog(context.Background(), catalog, "missing"); !errors.Is(err, ErrCatalogResourceNotFound) {
+		t.Errorf("Expected ErrCatalogResourceNotFound, got %v", err)
+	}
+}
+
+func TestFetchContentHash(t *testing.T) {
+	content := []byte("premium content")
+	sum := sha256.Sum256(content)
+	delivered := content
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			settleJSON, _ := json.Marshal(x402.SettleResponse{Success: true, Transaction: "0xabc", Network: "test:1"})
+			w.Header().Set("PAYMENT-RESPONSE", base64.StdEncoding.EncodeToString(settleJSON))
+			_, _ = w.Write(delivered)
+			return
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{{
+				Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest", MaxTimeoutSeconds: 30,
+				Extra: map[string]interface{}{ExtraContentHash: "sha256:" + hex.EncodeToString(sum[:])},
+			}},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	client := Newx402HTTPClient(x402Client)
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	result, err := client.Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(result.Response.Body)
+	result.Response.Body.Close()
+	if string(body) != string(content) {
+		t.Errorf("Expected verified body %q, got %q", content, body)
+	}
+
+	delivered = []byte("swapped content")
+	req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	_, err = client.Fetch(context.Background(), req)
+	if !errors.Is(err, ErrContentHashMismatch) {
+		t.Fatalf("Expected ErrContentHashMismatch, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "0xabc") {
+		t.Errorf("Expected the error to name the settlement transaction, got %v", err)
+	}
+}
+
+func TestEntitlementCache(t *testing.T) {
+	window := int64(3600)
+	granted := map[string]bool{}
+	rejectReplays := 0
+	var paid int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature := r.Header.Get("PAYMENT-SIGNATURE")
+		switch {
+		case signature == "":
+		case granted[signature] && rejectReplays > 0:
+			// The mock scheme signs deterministically, so the replay and the
+			// fresh payment look the same, only the replay is rejected
+			rejectReplays--
+			delete(granted, signature)
+		case granted[signature]:
+			w.WriteHeader(http.StatusOK)
+			return
+		default:
+			paid++
+			granted[signature] = true
+			settleJSON, _ := json.Marshal(x402.SettleResponse{
+				Success: true, Transaction: "0xabc", Network: "test:1", AccessWindowSeconds: window,
+			})
+			w.Header().Set("PAYMENT-RESPONSE", base64.StdEncoding.EncodeToString(settleJSON))
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{{
+				Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest", MaxTimeoutSeconds: 30,
+			}},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	client := Newx402HTTPClient(x402Client, WithEntitlementCache(time.Hour))
+	fetch := func() PaymentOutcome {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/article", nil)
+		result, err := client.Fetch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		result.Response.Body.Close()
+		if result.Response.StatusCode != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", result.Response.StatusCode)
+		}
+		return result.Outcome
+	}
+
+	if outcome := fetch(); outcome != PaymentOutcomePaid || paid != 1 {
+		t.Fatalf("Expected the first request to pay, got %s with %d payments", outcome, paid)
+	}
+	// The second request within the window reuses the entitlement
+	if outcome := fetch(); outcome != PaymentOutcomeCachedEntitlement || paid != 1 {
+		t.Fatalf("Expected a cached entitlement, got %s with %d payments", outcome, paid)
+	}
+
+	// An entitlement the server no longer accepts is dropped and paid again
+	rejectReplays = 1
+	if outcome := fetch(); outcome != PaymentOutcomePaid || paid != 2 {
+		t.Fatalf("Expected a rejected entitlement to be paid again, got %s with %d payments", outcome, paid)
+	}
+
+	// Without an access window granted by the server nothing is cached
+	window = 0
+	granted = map[string]bool{}
+	client = Newx402HTTPClient(x402Client, WithEntitlementCache(time.Hour))
+	fetch()
+	granted = map[string]bool{}
+	if outcome := fetch(); outcome != PaymentOutcomePaid || paid != 4 {
+		t.Fatalf("Expected every request to pay without a window, got %s with %d payments", outcome, paid)
+	}
+
+	// The client caps the windows servers grant
+	capped := &entitlementCache{maxWindow: time.Minute}
+	if got := capped.accessWindow(&x402.SettleResponse{AccessWindowSeconds: 3600}); got != time.Minute {
+		t.Errorf("Expected the window to be capped to 1m, got %s", got)
+	}
+}
+
+func TestTrustedFacilitators(t *testing.T) {
+	facilitator := "https://evil.example.net/x402"
+	var paid int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PAYMENT-SIGNATURE") != "" {
+			paid++
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		extra := map[string]interface{}{}
+		if facilitator != "" {
+			extra[ExtraFacilitator] = facilitator
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{{
+				Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest", MaxTimeoutSeconds: 30,
+				Extra: extra,
+			}},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", &mockSchemeClient{scheme: "mock"})
+	fetch := func(client *x402HTTPClient) error {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		resp, err := client.DoWithPayment(context.Background(), req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// Every facilitator is trusted by default
+	if err := fetch(Newx402HTTPClient(x402Client)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	client := Newx402HTTPClient(x402Client, WithTrustedFacilitators("x402.org", "*.coinbase.com"))
+	err := fetch(client)
+	if !errors.Is(err, ErrUntrustedFacilitator) {
+		t.Fatalf("Expected ErrUntrustedFacilitator, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "evil.example.net") {
+		t.Errorf("Expected the error to name the facilitator host, got %v", err)
+	}
+	if paid != 1 {
+		t.Errorf("Expected the untrusted challenge to stay unpaid, got %d payments", paid)
+	}
+
+	for _, trusted := range []string{"https://X402.org/facilitator", "https://api.cdp.coinbase.com:8443", ""} {
+		facilitator = trusted
+		if err := fetch(client); err != nil {
+			t.Errorf("Expected facilitator %q to be trusted, got %v", trusted, err)
+		}
+	}
+
+	facilitator = "https://coinbase.com.evil.net"
+	if err := fetch(client); !errors.Is(err, ErrUntrustedFacilitator) {
+		t.Errorf("Expected ErrUntrustedFacilitator for a lookalike host, got %v", err)
+	}
+}
+
+func TestDoWithPayment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("Success"))
+	}))
+	defer server.Close()
+
+	client := Newx402HTTPClient(x402.Newx402Client())
+	ctx := context.Background()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+
+	resp, err := client.DoWithPayment(ctx, req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetWithPayment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET, got %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := Newx402HTTPClient(x402.Newx402Client())
+	ctx := context.Background()
+
+	resp, err := client.GetWithPayment(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+}
+
+func TestPostWithPayment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "test body" {
+			t.Errorf("Expected 'test body', got %s", string(body))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := Newx402HTTPClient(x402.Newx402Client())
+	ctx := context.Background()
+
+	resp, err := client.PostWithPayment(ctx, server.URL, strings.NewReader("test body"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+}
+
+// Mock scheme client for testing
+type mockSchemeClient struct {
+	scheme string
+}
+
+func (m *mockSchemeClient) Scheme() string {
+	return m.scheme
+}
+
+func (m *mockSchemeClient) CreatePaymentPayload(ctx context.Context, requirements types.PaymentRequirements) (types.PaymentPayload, error) {
+	return types.PaymentPayload{
+		X402Version: 2,
+		Payload:     map[string]interface{}{"mock": "payload"},
+	}, nil
+}
+
+type mockPriceOracle struct {
+	rate       *big.Rat
+	observedAt time.Time
+}
+
+func (o *mockPriceOracle) Rate(ctx context.Context, currency string, network x402.Network, asset string) (*big.Rat, time.Time, error) {
+	if currency != "USD" {
+		return nil, time.Time{}, errors.New("unsupported currency")
+	}
+	return o.rate, o.observedAt, nil
+}
+
+func TestFetchFiatPrice(t *testing.T) {
+	var paidAmount atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if header := r.Header.Get("PAYMENT-SIGNATURE"); header != "" {
+			decoded, _ := base64.StdEncoding.DecodeString(header)
+			var payload x402.PaymentPayload
+			_ = json.Unmarshal(decoded, &payload)
+			paidAmount.Store(payload.Accepted.Amount)
+			_, _ = w.Write([]byte("report"))
+			return
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{{
+				Scheme: "exact", Network: "eip155:8453", Asset: "0xusdc", Amount: "1", PayTo: "0xmerchant",
+				Extra: map[string]interface{}{ExtraFiatPrice: map[string]interface{}{"amount": "1", "currency": "USD"}},
+			}},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	observedAt := time.Now().Add(-10 * time.Second)
+	oracle := &mockPriceOracle{rate: big.NewRat(1000000, 1), observedAt: observedAt}
+	client := NewSandboxClient(WithPriceOracle(oracle, time.Minute))
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	result, err := client.Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer result.Response.Body.Close()
+	if result.Outcome != PaymentOutcomePaid {
+		t.Fatalf("Expected a paid outcome, got %s", result.Outcome)
+	}
+	if got := paidAmount.Load(); got != "1000000" {
+		t.Errorf("Expected $1 to be paid as 1000000 base units, got %v", got)
+	}
+	conversion := result.FiatConversion
+	if conversion == nil {
+		t.Fatal("Expected the fiat conversion to be recorded")
+	}
+	if conversion.Amount != "1000000" || conversion.Currency != "USD" || conversion.FiatAmount != "1" || conversion.Rate != "1000000" {
+		t.Errorf("Unexpected conversion: %+v", conversion)
+	}
+	if !conversion.RateObservedAt.Equal(observedAt) {
+		t.Errorf("Expected rate timestamp %v, got %v", observedAt, conversion.RateObservedAt)
+	}
+	if result.Requirements == nil || result.Requirements.Amount != "1000000" {
+		t.Errorf("Expected recorded requirements to carry the converted amount, got %+v", result.Requirements)
+	}
+
+	// A fractional base unit is rounded up
+	oracle.rate = big.NewRat(2000001, 2)
+	req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	result, err = client.Fetch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result.Response.Body.Close()
+	if got := paidAmount.Load(); got != "1000001" {
+		t.Errorf("Expected the converted amount to be rounded up to 1000001, got %v", got)
+	}
+
+	// A stale rate aborts before paying
+	paidAmount.Store("")
+	oracle.observedAt = time.Now().Add(-time.Hour)
+	req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	if _, err := client.Fetch(context.Background(), req); !errors.Is(err, ErrStaleRate) {
+		t.Errorf("Expected ErrStaleRate, got %v", err)
+	}
+	if got := paidAmount.Load(); got != "" {
+		t.Errorf("Expected no payment with a stale rate, got %v", got)
+	}
+}
+
+// Mock scheme client signing a fresh authorization on every call
+type signingSchemeClient struct {
+	signed atomic.Int32
+}
+
+func (m *signingSchemeClient) Scheme() string {
+	return "mock"
+}
+
+func (m *signingSchemeClient) CreatePaymentPayload(ctx context.Context, requirements types.PaymentRequirements) (types.PaymentPayload, error) {
+	nonce := m.signed.Add(1)
+	return types.PaymentPayload{
+		X402Version: 2,
+		Payload:     map[string]interface{}{"nonce": nonce},
+	}, nil
+}
+
+func TestPaymentRoundTripperIdempotency(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payments []string
+		keys     []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if header := r.Header.Get("PAYMENT-SIGNATURE"); header != "" {
+			payments = append(payments, header)
+			if len(payments) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			settleJSON, _ := json.Marshal(x402.SettleResponse{Success: true, Transaction: "0xabc", Network: "test:1"})
+			w.Header().Set("PAYMENT-RESPONSE", base64.StdEncoding.EncodeToString(settleJSON))
+			_, _ = w.Write([]byte("report"))
+			return
+		}
+		requirements := x402.PaymentRequired{
+			X402Version: 2,
+			Accepts: []x402.PaymentRequirements{
+				{Scheme: "mock", Network: "test:1", Asset: "TEST", Amount: "1000", PayTo: "0xtest", MaxTimeoutSeconds: 30},
+			},
+		}
+		reqJSON, _ := json.Marshal(requirements)
+		w.Header().Set("PAYMENT-REQUIRED", base64.StdEncoding.EncodeToString(reqJSON))
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	scheme := &signingSchemeClient{}
+	x402Client := x402.Newx402Client()
+	x402Client.Register("test:1", scheme)
+	client := WrapHTTPClientWithPayment(&http.Client{}, Newx402HTTPClient(x402Client, WithIdempotency(time.Minute)))
+
+	key, err := NewIdempotencyKey()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := WithRequestOptions(context.Background(), RequestOptions{IdempotencyKey: key})
+	get := func(ctx context.Context) int {
+		req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// The retry of a transient failure resends the same signed payment
+	if code := get(ctx); code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d", code)
+	}
+	if code := get(ctx); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if n := scheme.signed.Load(); n != 1 {
+		t.Errorf("Expected a single signed payment, got %d", n)
+	}
+	mu.Lock()
+	if len(payments) != 2 || payments[0] != payments[1] {
+		t.Errorf("Expected the retry to resend the same payment, got %v", payments)
+	}
+	for _, got := range keys {
+		if got != key {
+			t.Errorf("Expected every request to carry idempotency key %s, got %q", key, got)
+		}
+	}
+	mu.Unlock()
+
+	// A succeeded request no longer holds its payment
+	if code := get(ctx); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if n := scheme.signed.Load(); n != 2 {
+		t.Errorf("Expected a new payment after success, got %d signed", n)
+	}
+
+	// Requests without a key get a generated one
+	mu.Lock()
+	keys = nil
+	mu.Unlock()
+	get(context.Background())
+	mu.Lock()
+	defer mu.Unlock()
+	if len(keys) == 0 || len(keys[0]) != 32 || keys[0] == key {
+		t.Errorf("Expected a generated idempotency key, got %v", keys)
+	}
 }
 
 
@@ -0,0 +1,71 @@
+// ucm:14.9.3.8:nich
+
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ============================================================================
+// Content Hash Verification
+// ============================================================================
+
+// ExtraContentHash is the requirements Extra key advertising the SHA-256 hash
+// of the paid content, hex encoded and optionally prefixed with "sha256:"
+const ExtraContentHash = "contentHash"
+
+// ErrContentHashMismatch is returned by Fetch when a paid response body does
+// not match the content hash advertised in the challenge
+var ErrContentHashMismatch = errors.New("content hash mismatch")
+
+// contentHash returns the hex SHA-256 hash advertised in requirements, if any
+func contentHash(requirements *PaymentRequirements) (string, error) {
+	if requirements == nil {
+		return "", nil
+	}
+	advertised, _ := requirements.Extra[ExtraContentHash].(string)
+	if advertised == "" {
+		return "", nil
+	}
+
+	algorithm, digest, ok := strings.Cut(advertised, ":")
+	if !ok {
+		algorithm, digest = "sha256", advertised
+	}
+	if !strings.EqualFold(algorithm, "sha256") {
+		return "", fmt.Errorf("unsupported content hash algorithm %q", algorithm)
+	}
+	return strings.ToLower(digest), nil
+}
+
+// verifyContentHash reads the body of resp and compares it to the content hash
+// advertised in requirements. The body is replaced so it can still be read
+func verifyContentHash(resp *http.Response, requirements *PaymentRequirements) error {
+	want, err := contentHash(requirements)
+	if err != nil || want == "" {
+		return err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.Sum256(body)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("%w: expected sha256 %s, got %s", ErrContentHashMismatch, want, got)
+	}
+	return nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
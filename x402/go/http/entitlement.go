@@ -0,0 +1,132 @@
+/* entitlement.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	x402 "github.com/coinbase/x402/go"
+)
+
+// ============================================================================
+// Entitlement Cache
+// ============================================================================
+
+// entitlement is the payment that granted access to a resource until expires
+type entitlement struct {
+	headers map[string]string
+	expires time.Time
+}
+
+// entitlementCache maps resource URLs to the entitlements granted for them
+type entitlementCache struct {
+	mu        sync.Mutex
+	maxWindow time.Duration
+	entries   map[string]entitlement
+}
+
+// WithEntitlementCache remembers the payment header of a settled request for
+// the access window the server granted with it, the AccessWindowSeconds of
+// its SettleResponse. A later 402 for the same resource within the window is
+// answered with that payment header instead of paying again, and the outcome
+// is PaymentOutcomeCachedEntitlement. If the server rejects it, the challenge
+// is paid as usual.
+//
+// Payments settled without an access window are not cached. A maxWindow above
+// 0 caps the windows granted by servers
+func WithEntitlementCache(maxWindow time.Duration) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.entitlements = &entitlementCache{
+			maxWindow: maxWindow,
+			entries:   make(map[string]entitlement),
+		}
+	}
+}
+
+// entitlementKey is the resource an entitlement is granted for
+func entitlementKey(req *http.Request) string {
+	return req.URL.String()
+}
+
+// accessWindow returns the access window granted by settlement
+func (e *entitlementCache) accessWindow(settlement *x402.SettleResponse) time.Duration {
+	window := time.Duration(settlement.AccessWindowSeconds) * time.Second
+	if e.maxWindow > 0 {
+		window = min(window, e.maxWindow)
+	}
+	return window
+}
+
+// grant records the payment headers settled for req
+func (e *entitlementCache) grant(req *http.Request, settlement *x402.SettleResponse, headers map[string]string) {
+	window := e.accessWindow(settlement)
+	if window <= 0 {
+		return
+	}
+
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for key, entry := range e.entries {
+		if !now.Before(entry.expires) {
+			delete(e.entries, key)
+		}
+	}
+	e.entries[entitlementKey(req)] = entitlement{headers: headers, expires: now.Add(window)}
+}
+
+// lookup returns the payment headers of a live entitlement for req
+func (e *entitlementCache) lookup(req *http.Request) (map[string]string, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	key := entitlementKey(req)
+	entry, ok := e.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(entry.expires) {
+		delete(e.entries, key)
+		return nil, false
+	}
+	return entry.headers, true
+}
+
+// revoke forgets the entitlement for req, e.g. when the server rejected it
+func (e *entitlementCache) revoke(req *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.entries, entitlementKey(req))
+}
+
+// entitledRoundTrip answers a 402 for req with the payment of a live
+// entitlement. It returns nil when there is none or the server rejected it
+func (t *PaymentRoundTripper) entitledRoundTrip(req *http.Request) (*http.Response, error) {
+	cache := t.x402Client.entitlements
+	if cache == nil {
+		return nil, nil
+	}
+	headers, ok := cache.lookup(req)
+	if !ok {
+		return nil, nil
+	}
+
+	entitledReq := req.Clone(req.Context())
+	for k, v := range headers {
+		entitledReq.Header.Set(k, v)
+	}
+	resp, err := t.Transport.RoundTrip(entitledReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusPaymentRequired {
+		resp.Body.Close()
+		cache.revoke(req)
+		return nil, nil
+	}
+	return resp, nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,68 @@
+/* facilitator_allowlist.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ============================================================================
+// Facilitator Allowlist
+// ============================================================================
+
+// ExtraFacilitator is the requirements Extra key a resource server uses to
+// advertise the URL of the facilitator that verifies and settles its payments
+const ExtraFacilitator = "facilitator"
+
+// ErrUntrustedFacilitator is returned when a challenge points to a facilitator
+// whose host is not in the client's allowlist
+var ErrUntrustedFacilitator = errors.New("untrusted facilitator")
+
+// WithTrustedFacilitators restricts the facilitators a challenge may point to
+// via ExtraFacilitator. Hosts match case-insensitively, with or without port,
+// and "*.example.com" matches any subdomain of example.com. Challenges that
+// do not advertise a facilitator are unaffected. By default every facilitator
+// is trusted
+func WithTrustedFacilitators(hosts ...string) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.trustedFacilitators = make([]string, 0, len(hosts))
+		for _, host := range hosts {
+			c.trustedFacilitators = append(c.trustedFacilitators, strings.ToLower(strings.TrimSpace(host)))
+		}
+	}
+}
+
+// checkFacilitator refuses requirements advertising a facilitator outside the
+// allowlist configured with WithTrustedFacilitators
+func (c *x402HTTPClient) checkFacilitator(extra map[string]interface{}) error {
+	if c.trustedFacilitators == nil {
+		return nil
+	}
+	raw, ok := extra[ExtraFacilitator]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	advertised, _ := raw.(string)
+	u, err := url.Parse(advertised)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: invalid facilitator URL %q", ErrUntrustedFacilitator, advertised)
+	}
+	host := strings.ToLower(u.Host)
+	hostname := strings.ToLower(u.Hostname())
+	for _, trusted := range c.trustedFacilitators {
+		if trusted == host || trusted == hostname {
+			return nil
+		}
+		if suffix, ok := strings.CutPrefix(trusted, "*"); ok && strings.HasPrefix(suffix, ".") && strings.HasSuffix(hostname, suffix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUntrustedFacilitator, host)
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	x402 "github.com/coinbase/x402/go"
@@ -26,6 +27,7 @@ type HTTPFacilitatorClient struct {
 	httpClient   *http.Client
 	authProvider AuthProvider
 	identifier   string
+	refundSigner RefundSigner
 }
 
 // AuthProvider generates authentication headers for facilitator requests
@@ -57,6 +59,28 @@ type FacilitatorConfig struct {
 
 	// Identifier for this facilitator (optional)
 	Identifier string
+
+	// RefundSigner signs refund requests, see RequestRefund (optional)
+	RefundSigner RefundSigner
+}
+
+// FacilitatorStatusError is returned when the facilitator answers a verify or
+// settle request with an error status and no payment verdict
+type FacilitatorStatusError struct {
+	Op         string
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface
+func (e *FacilitatorStatusError) Error() string {
+	return fmt.Sprintf("facilitator %s failed (%d): %s", e.Op, e.StatusCode, e.Body)
+}
+
+// Temporary reports whether the facilitator failed with a 5xx status, which
+// is worth retrying for idempotent calls
+func (e *FacilitatorStatusError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError
 }
 
 // DefaultFacilitatorURL is the default public facilitator
@@ -95,6 +119,7 @@ func NewHTTPFacilitatorClient(config *FacilitatorConfig) *HTTPFacilitatorClient
 		httpClient:   httpClient,
 		authProvider: config.AuthProvider,
 		identifier:   identifier,
+		refundSigner: config.RefundSigner,
 	}
 }
 
@@ -167,6 +192,19 @@ func (c *HTTPFacilitatorClient) GetSupported(ctx context.Context) (x402.Supporte
 	return supportedResponse, nil
 }
 
+// BuildSettleRequest returns the fully-formed HTTP request Settle would send
+// to the facilitator for auth (URL, headers, body) without sending it
+// Useful for debugging or for proxies that route the request themselves
+func (c *HTTPFacilitatorClient) BuildSettleRequest(ctx context.Context, auth SignedAuthorization) (*http.Request, error) {
+	// Detect version from bytes
+	version, err := types.DetectVersion(auth.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect version: %w", err)
+	}
+
+	return c.newSettleRequest(ctx, version, auth.Payload, auth.Requirements)
+}
+
 // ============================================================================
 // Internal HTTP Methods (shared by V1 and V2)
 // ============================================================================
@@ -225,7 +263,7 @@ func (c *HTTPFacilitatorClient) verifyHTTP(ctx context.Context, version int, pay
 
 	var verifyResponse x402.VerifyResponse
 	if err := json.Unmarshal(responseBody, &verifyResponse); err != nil {
-		return nil, fmt.Errorf("facilitator verify failed (%d): %s", resp.StatusCode, string(responseBody))
+		return nil, &FacilitatorStatusError{Op: "verify", StatusCode: resp.StatusCode, Body: string(responseBody)}
 	}
 
 	// For non-200 responses, return an error with the details from the response
@@ -238,13 +276,14 @@ func (c *HTTPFacilitatorClient) verifyHTTP(ctx context.Context, version int, pay
 				fmt.Errorf("facilitator returned %d", resp.StatusCode),
 			)
 		}
-		return nil, fmt.Errorf("facilitator verify failed (%d): %s", resp.StatusCode, string(responseBody))
+		return nil, &FacilitatorStatusError{Op: "verify", StatusCode: resp.StatusCode, Body: string(responseBody)}
 	}
 
 	return &verifyResponse, nil
 }
 
-func (c *HTTPFacilitatorClient) settleHTTP(ctx context.Context, version int, payloadBytes, requirementsBytes []byte) (*x402.SettleResponse, error) {
+// newSettleRequest builds the settle request, including auth headers
+func (c *HTTPFacilitatorClient) newSettleRequest(ctx context.Context, version int, payloadBytes, requirementsBytes []byte) (*http.Request, error) {
 	// Build request body
 	var payloadMap, requirementsMap map[string]interface{}
 	if err := json.Unmarshal(payloadBytes, &payloadMap); err != nil {
@@ -284,6 +323,15 @@ func (c *HTTPFacilitatorClient) settleHTTP(ctx context.Context, version int, pay
 		}
 	}
 
+	return req, nil
+}
+
+func (c *HTTPFacilitatorClient) settleHTTP(ctx context.Context, version int, payloadBytes, requirementsBytes []byte) (*x402.SettleResponse, error) {
+	req, err := c.newSettleRequest(ctx, version, payloadBytes, requirementsBytes)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -298,7 +346,7 @@ func (c *HTTPFacilitatorClient) settleHTTP(ctx context.Context, version int, pay
 
 	var settleResponse x402.SettleResponse
 	if err := json.Unmarshal(responseBody, &settleResponse); err != nil {
-		return nil, fmt.Errorf("facilitator settle failed (%d): %s", resp.StatusCode, string(responseBody))
+		return nil, &FacilitatorStatusError{Op: "settle", StatusCode: resp.StatusCode, Body: string(responseBody)}
 	}
 
 	// For non-200 responses, return an error with the details from the response
@@ -312,11 +360,124 @@ func (c *HTTPFacilitatorClient) settleHTTP(ctx context.Context, version int, pay
 				fmt.Errorf("facilitator returned %d", resp.StatusCode),
 			)
 		}
-		return nil, fmt.Errorf("facilitator settle failed (%d): %s", resp.StatusCode, string(responseBody))
+		return nil, &FacilitatorStatusError{Op: "settle", StatusCode: resp.StatusCode, Body: string(responseBody)}
 	}
 
 	return &settleResponse, nil
 }
 
+// ============================================================================
+// Settlement Status Streaming
+// ============================================================================
+
+// SettlementState is the progress of a settlement reported by the facilitator
+type SettlementState string
+
+const (
+	SettlementPending   SettlementState = "pending"
+	SettlementBroadcast SettlementState = "broadcast"
+	SettlementConfirmed SettlementState = "confirmed"
+	SettlementFailed    SettlementState = "failed"
+)
+
+// IsTerminal reports whether no further transitions follow this state
+func (s SettlementState) IsTerminal() bool {
+	return s == SettlementConfirmed || s == SettlementFailed
+}
+
+// SettlementStatus is a single status transition of a settlement
+type SettlementStatus struct {
+	State       SettlementState `json:"status"`
+	Transaction string          `json:"transaction,omitempty"`
+	Network     x402.Network    `json:"network,omitempty"`
+	ErrorReason string          `json:"errorReason,omitempty"`
+
+	// Err is set on the last status sent when the stream broke before a terminal state
+	Err error `json:"-"`
+}
+
+// WatchSettlement subscribes to the facilitator's SSE status stream for a settlement
+// Status transitions are sent on the returned channel, which is closed after a
+// terminal state, when the stream ends or when ctx is done
+func (c *HTTPFacilitatorClient) WatchSettlement(ctx context.Context, id string) (<-chan SettlementStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url+"/settlements/"+url.PathEscape(id)+"/events", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create settlement events request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	// Add auth headers if available
+	if c.authProvider != nil {
+		authHeaders, err := c.authProvider.GetAuthHeaders(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get auth headers: %w", err)
+		}
+		for k, v := range authHeaders.Settle {
+			req.Header.Set(k, v)
+		}
+	}
+
+	// The stream outlives the client timeout, ctx bounds it instead
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("settlement events request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		responseBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("facilitator settlement events failed (%d): %s", resp.StatusCode, string(responseBody))
+	}
+
+	statuses := make(chan SettlementStatus)
+	go func() {
+		defer close(statuses)
+		defer resp.Body.Close()
+
+		var last SettlementStatus
+		send := func(status SettlementStatus) bool {
+			select {
+			case statuses <- status:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var decodeErr error
+		err := readSSE(resp.Body, func(event sseEvent) bool {
+			if event.Event != "" && event.Event != "message" && event.Event != "status" {
+				return true
+			}
+			var status SettlementStatus
+			if decodeErr = json.Unmarshal([]byte(event.Data), &status); decodeErr != nil {
+				decodeErr = fmt.Errorf("failed to decode settlement status: %w", decodeErr)
+				return false
+			}
+			last = status
+			return send(status) && !status.State.IsTerminal()
+		})
+		if last.State.IsTerminal() || ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case decodeErr != nil:
+			last.Err = decodeErr
+		case err != nil:
+			last.Err = fmt.Errorf("settlement events stream failed: %w", err)
+		default:
+			last.Err = fmt.Errorf("settlement events stream ended before a terminal state")
+		}
+		send(last)
+	}()
+
+	return statuses, nil
+}
+
 
 /* EOF - universal-crypto-mcp | 0.4.14.3 */
\ No newline at end of file
@@ -12,11 +12,15 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	x402 "github.com/coinbase/x402/go"
@@ -287,6 +291,159 @@ func TestHTTPFacilitatorClientSettle(t *testing.T) {
 	}
 }
 
+// mockRefundSigner signs with sha256(key || message)
+type mockRefundSigner struct {
+	address string
+	key     string
+}
+
+func (m *mockRefundSigner) Address() string {
+	return m.address
+}
+
+func (m *mockRefundSigner) SignRefund(ctx context.Context, message []byte) ([]byte, error) {
+	sum := sha256.Sum256(append([]byte(m.key), message...))
+	return sum[:], nil
+}
+
+func TestHTTPFacilitatorClientRequestRefund(t *testing.T) {
+	ctx := context.Background()
+	signer := &mockRefundSigner{address: "0xPayer", key: "secret"}
+
+	var received RefundRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/refund" || r.Method != "POST" {
+			t.Errorf("Expected POST /refund, got %s %s", r.Method, r.URL.Path)
+		}
+		received = RefundRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("Failed to decode refund request: %v", err)
+		}
+
+		// Check the signature over the request without it
+		unsigned := received
+		unsigned.Signature = ""
+		message, _ := json.Marshal(unsigned)
+		want, _ := signer.SignRefund(ctx, message)
+		if received.Signature != "0x"+hex.EncodeToString(want) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(RefundResponse{ErrorReason: "invalid_signature"})
+			return
+		}
+
+		amount := received.Amount
+		if amount == "" {
+			amount = "1000000"
+		}
+		_ = json.NewEncoder(w).Encode(RefundResponse{Success: true, Transaction: "0xrefundtx", Network: received.Network, Amount: amount})
+	}))
+	defer server.Close()
+
+	client := NewHTTPFacilitatorClient(&FacilitatorConfig{URL: server.URL, RefundSigner: signer})
+	receipt := &x402.SettleResponse{Success: true, Transaction: "0xsettledtx", Payer: "0xpayer", Network: "eip155:8453"}
+
+	response, err := client.RequestRefund(ctx, receipt, big.NewInt(250000))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !response.Success || response.Transaction != "0xrefundtx" || response.Amount != "250000" {
+		t.Errorf("Unexpected refund response: %+v", response)
+	}
+	if received.X402Version != 2 || received.Network != "eip155:8453" || received.Transaction != "0xsettledtx" || received.Payer != "0xPayer" {
+		t.Errorf("Unexpected refund request: %+v", received)
+	}
+	if len(received.Nonce) != 66 || received.Timestamp == 0 {
+		t.Errorf("Expected a nonce and timestamp, got %+v", received)
+	}
+
+	// A nil amount requests a full refund
+	response, err = client.RequestRefund(ctx, receipt, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if received.Amount != "" || response.Amount != "1000000" {
+		t.Errorf("Expected a full refund, got request %+v, response %+v", received, response)
+	}
+
+	// Signed with another key
+	forged := NewHTTPFacilitatorClient(&FacilitatorConfig{URL: server.URL, RefundSigner: &mockRefundSigner{address: "0xpayer", key: "other"}})
+	if _, err := forged.RequestRefund(ctx, receipt, nil); err == nil || !strings.Contains(err.Error(), "invalid_signature") {
+		t.Errorf("Expected invalid_signature error, got %v", err)
+	}
+
+	if _, err := NewHTTPFacilitatorClient(&FacilitatorConfig{URL: server.URL}).RequestRefund(ctx, receipt, nil); !errors.Is(err, ErrRefundSignerRequired) {
+		t.Errorf("Expected ErrRefundSignerRequired, got %v", err)
+	}
+	if _, err := client.RequestRefund(ctx, receipt, big.NewInt(0)); err == nil {
+		t.Error("Expected error for a zero amount")
+	}
+	if _, err := client.RequestRefund(ctx, &x402.SettleResponse{Success: true, Transaction: "0xtx", Payer: "0xother"}, nil); err == nil {
+		t.Error("Expected error for another payer's receipt")
+	}
+}
+
+func TestHTTPFacilitatorClientBuildSettleRequest(t *testing.T) {
+	ctx := context.Background()
+
+	client := NewHTTPFacilitatorClient(&FacilitatorConfig{
+		URL:          "https://facilitator.example.com",
+		AuthProvider: NewStaticAuthProvider("api-key-123"),
+	})
+
+	requirements := x402.PaymentRequirements{
+		Scheme:  "exact",
+		Network: "eip155:1",
+		Asset:   "USDC",
+		Amount:  "1000000",
+		PayTo:   "0xrecipient",
+	}
+
+	payload := x402.PaymentPayload{
+		X402Version: 2,
+		Accepted:    requirements,
+		Payload:     map[string]interface{}{"signature": "0xsig"},
+	}
+
+	payloadBytes, _ := json.Marshal(payload)
+	requirementsBytes, _ := json.Marshal(requirements)
+
+	req, err := client.BuildSettleRequest(ctx, SignedAuthorization{Payload: payloadBytes, Requirements: requirementsBytes})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if req.Method != "POST" {
+		t.Errorf("Expected POST, got %s", req.Method)
+	}
+	if req.URL.String() != "https://facilitator.example.com/settle" {
+		t.Errorf("Expected settle URL, got %s", req.URL)
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected JSON content type, got %s", req.Header.Get("Content-Type"))
+	}
+	if req.Header.Get("Authorization") != "Bearer api-key-123" {
+		t.Errorf("Expected auth header, got %s", req.Header.Get("Authorization"))
+	}
+
+	var body struct {
+		X402Version         int                      `json:"x402Version"`
+		PaymentPayload      x402.PaymentPayload      `json:"paymentPayload"`
+		PaymentRequirements x402.PaymentRequirements `json:"paymentRequirements"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode request body: %v", err)
+	}
+	if body.X402Version != 2 {
+		t.Errorf("Expected x402Version 2, got %d", body.X402Version)
+	}
+	if body.PaymentPayload.Payload["signature"] != "0xsig" {
+		t.Errorf("Expected payload signature 0xsig, got %v", body.PaymentPayload.Payload["signature"])
+	}
+	if body.PaymentRequirements.Amount != "1000000" {
+		t.Errorf("Expected amount 1000000, got %s", body.PaymentRequirements.Amount)
+	}
+}
+
 func TestHTTPFacilitatorClientGetSupported(t *testing.T) {
 	ctx := context.Background()
 
@@ -535,6 +692,85 @@ func TestHTTPFacilitatorClient400WithValidResponse(t *testing.T) {
 	}
 }
 
+func TestHTTPFacilitatorClientWatchSettlement(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/settlements/settle-123/events" {
+			t.Errorf("Expected path /settlements/settle-123/events, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected Accept text/event-stream, got %s", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		events := []string{
+			`{"status":"pending"}`,
+			`{"status":"broadcast","transaction":"0xabc"}`,
+			`{"status":"confirmed","transaction":"0xabc","network":"eip155:8453"}`,
+		}
+		for _, data := range events {
+			fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	client := NewHTTPFacilitatorClient(&FacilitatorConfig{URL: server.URL})
+
+	statuses, err := client.WatchSettlement(context.Background(), "settle-123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got []SettlementStatus
+	for status := range statuses {
+		got = append(got, status)
+	}
+
+	want := []SettlementState{SettlementPending, SettlementBroadcast, SettlementConfirmed}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d statuses, got %d", len(want), len(got))
+	}
+	for i, status := range got {
+		if status.State != want[i] {
+			t.Errorf("Status %d: expected %s, got %s", i, want[i], status.State)
+		}
+		if status.Err != nil {
+			t.Errorf("Status %d: unexpected error: %v", i, status.Err)
+		}
+	}
+	if got[2].Transaction != "0xabc" || got[2].Network != x402.NetworkBase {
+		t.Errorf("Unexpected confirmed status: %+v", got[2])
+	}
+}
+
+func TestHTTPFacilitatorClientWatchSettlementStreamEnded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\ndata: {\"status\":\"pending\"}\n\n")
+	}))
+	defer server.Close()
+
+	client := NewHTTPFacilitatorClient(&FacilitatorConfig{URL: server.URL})
+
+	statuses, err := client.WatchSettlement(context.Background(), "settle-123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got []SettlementStatus
+	for status := range statuses {
+		got = append(got, status)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 statuses, got %d", len(got))
+	}
+	if got[1].State != SettlementPending || got[1].Err == nil {
+		t.Errorf("Expected last status to repeat pending with an error, got %+v", got[1])
+	}
+}
+
 func TestStaticAuthProvider(t *testing.T) {
 	provider := NewStaticAuthProvider("api-key-123")
 
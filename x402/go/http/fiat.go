@@ -0,0 +1,118 @@
+/* fiat.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	x402 "github.com/coinbase/x402/go"
+)
+
+// ============================================================================
+// Fiat Prices
+// ============================================================================
+
+// ExtraFiatPrice is the requirements Extra key of a price quoted in fiat, e.g.
+// {"amount": "1.50", "currency": "USD"}. A client with a PriceOracle pays the
+// conversion of that price to the asset instead of the requirements amount
+const ExtraFiatPrice = "fiatPrice"
+
+var (
+	// ErrStaleRate is returned when the price oracle's rate is older than the
+	// staleness allowed with WithPriceOracle
+	ErrStaleRate = errors.New("price oracle rate is stale")
+
+	// ErrInvalidFiatPrice is returned when a challenge advertises a fiat price
+	// that cannot be converted
+	ErrInvalidFiatPrice = errors.New("invalid fiat price")
+)
+
+// PriceOracle converts fiat prices into amounts of the asset paid
+type PriceOracle interface {
+	// Rate returns how many of the smallest units of asset on network one
+	// unit of currency buys, e.g. 1000000 for USDC per USD, and when the rate
+	// was observed
+	Rate(ctx context.Context, currency string, network x402.Network, asset string) (rate *big.Rat, observedAt time.Time, err error)
+}
+
+// FiatConversion records how a fiat price was converted for a payment
+type FiatConversion struct {
+	FiatAmount     string    `json:"fiatAmount"`
+	Currency       string    `json:"currency"`
+	Rate           string    `json:"rate"` // Smallest asset units per unit of currency
+	RateObservedAt time.Time `json:"rateObservedAt"`
+	Amount         string    `json:"amount"` // In the asset's smallest unit, rounded up
+}
+
+// WithPriceOracle converts prices quoted in fiat via ExtraFiatPrice with
+// oracle, aborting with ErrStaleRate when its rate is older than maxStaleness.
+// A maxStaleness of 0 accepts any rate. Without an oracle the requirements
+// amount is paid as is
+func WithPriceOracle(oracle PriceOracle, maxStaleness time.Duration) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		c.priceOracle = oracle
+		c.maxRateStaleness = maxStaleness
+	}
+}
+
+// convertFiatPrice converts the fiat price advertised by requirements, if any
+// and an oracle is configured, recording the conversion for a Fetch in
+// progress. It returns nil when the requirements amount is paid as is
+func (c *x402HTTPClient) convertFiatPrice(ctx context.Context, requirements x402.PaymentRequirementsView) (*FiatConversion, error) {
+	if c.priceOracle == nil {
+		return nil, nil
+	}
+	raw, ok := requirements.GetExtra()[ExtraFiatPrice]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	price, _ := raw.(map[string]interface{})
+	currency, _ := price["currency"].(string)
+	var fiatAmount string
+	if amount, ok := price["amount"]; ok && amount != nil {
+		fiatAmount = fmt.Sprint(amount)
+	}
+	fiat, ok := new(big.Rat).SetString(fiatAmount)
+	if currency == "" || !ok || fiat.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFiatPrice, raw)
+	}
+
+	network := x402.ParseNetwork(requirements.GetNetwork())
+	rate, observedAt, err := c.priceOracle.Rate(ctx, currency, network, requirements.GetAsset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s rate of %s on %s: %w", currency, requirements.GetAsset(), network, err)
+	}
+	if rate == nil || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: non-positive %s rate", ErrInvalidFiatPrice, currency)
+	}
+	if age := time.Since(observedAt); c.maxRateStaleness > 0 && age > c.maxRateStaleness {
+		return nil, fmt.Errorf("%w: observed %s ago, max %s", ErrStaleRate, age.Round(time.Second), c.maxRateStaleness)
+	}
+
+	// Round up so the converted amount never falls short of the price
+	total := new(big.Rat).Mul(fiat, rate)
+	amount, rem := new(big.Int).QuoRem(total.Num(), total.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		amount.Add(amount, big.NewInt(1))
+	}
+
+	conversion := &FiatConversion{
+		FiatAmount:     fiatAmount,
+		Currency:       currency,
+		Rate:           rate.RatString(),
+		RateObservedAt: observedAt,
+		Amount:         amount.String(),
+	}
+	if state, ok := ctx.Value(fetchStateKey{}).(*fetchState); ok {
+		state.fiat = conversion
+	}
+	return conversion, nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
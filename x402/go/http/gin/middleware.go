@@ -102,6 +102,9 @@ type MiddlewareConfig struct {
 
 	// Context timeout for payment operations
 	Timeout time.Duration
+
+	// Payment recipient for RequirePayment
+	PayTo string
 }
 
 // SchemeRegistration registers a scheme with the server
@@ -165,6 +168,13 @@ func WithTimeout(timeout time.Duration) MiddlewareOption {
 	}
 }
 
+// WithPayTo sets the payment recipient used by RequirePayment
+func WithPayTo(payTo string) MiddlewareOption {
+	return func(c *MiddlewareConfig) {
+		c.PayTo = payTo
+	}
+}
+
 // ============================================================================
 // Payment Middleware
 // ============================================================================
@@ -216,6 +226,12 @@ func PaymentMiddlewareFromConfig(routes x402http.RoutesConfig, opts ...Middlewar
 		opt(config)
 	}
 
+	// Create middleware handler
+	return createMiddlewareHandler(newHTTPServer(config), config)
+}
+
+// newHTTPServer creates and optionally initializes the HTTP resource server for config.
+func newHTTPServer(config *MiddlewareConfig) *x402http.HTTPServer {
 	serverOpts := []x402.ResourceServerOption{}
 	for _, client := range config.FacilitatorClients {
 		serverOpts = append(serverOpts, x402.WithFacilitatorClient(client))
@@ -239,8 +255,7 @@ func PaymentMiddlewareFromConfig(routes x402http.RoutesConfig, opts ...Middlewar
 		}
 	}
 
-	// Create middleware handler
-	return createMiddlewareHandler(httpServer, config)
+	return httpServer
 }
 
 // createMiddlewareHandler creates the actual Gin handler function.
@@ -358,18 +373,7 @@ func handlePaymentVerified(c *gin.Context, server *x402http.HTTPServer, ctx cont
 
 	// Check settlement success
 	if !settleResult.Success {
-		errorReason := settleResult.ErrorReason
-		if errorReason == "" {
-			errorReason = "Settlement failed"
-		}
-		if config.ErrorHandler != nil {
-			config.ErrorHandler(c, fmt.Errorf("settlement failed: %s", errorReason))
-		} else {
-			c.JSON(http.StatusPaymentRequired, gin.H{
-				"error":   "Settlement failed",
-				"details": errorReason,
-			})
-		}
+		handleSettlementFailure(c, settleResult, config)
 		return
 	}
 
@@ -394,6 +398,22 @@ func handlePaymentVerified(c *gin.Context, server *x402http.HTTPServer, ctx cont
 	_, _ = c.Writer.Write(writer.body.Bytes())
 }
 
+// handleSettlementFailure responds to a failed settlement
+func handleSettlementFailure(c *gin.Context, settleResult *x402http.ProcessSettleResult, config *MiddlewareConfig) {
+	errorReason := settleResult.ErrorReason
+	if errorReason == "" {
+		errorReason = "Settlement failed"
+	}
+	if config.ErrorHandler != nil {
+		config.ErrorHandler(c, fmt.Errorf("settlement failed: %s", errorReason))
+	} else {
+		c.JSON(http.StatusPaymentRequired, gin.H{
+			"error":   "Settlement failed",
+			"details": errorReason,
+		})
+	}
+}
+
 // ============================================================================
 // Response Capture
 // ============================================================================
@@ -1041,6 +1041,147 @@ func TestX402Payment_RegistersMultipleSchemes(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// RequirePayment Tests
+// ============================================================================
+
+// createRequirePaymentRouter guards the /premium group with RequirePayment
+func createRequirePaymentRouter(mockClient *mockFacilitatorClient, handler gin.HandlerFunc) *gin.Engine {
+	router := createTestRouter()
+	router.GET("/free", func(c *gin.Context) {
+		c.String(http.StatusOK, "free")
+	})
+
+	premium := router.Group("/premium")
+	premium.Use(RequirePayment("$1.00", "", "eip155:1",
+		WithPayTo("0xtest"),
+		WithFacilitatorClient(mockClient),
+		WithScheme("eip155:1", &mockSchemeServer{scheme: "exact"}),
+		WithTimeout(5*time.Second),
+	))
+	premium.GET("/data", handler)
+	return router
+}
+
+func TestRequirePayment_Returns402WithoutPayment(t *testing.T) {
+	handlerCalled := false
+	router := createRequirePaymentRouter(&mockFacilitatorClient{}, func(c *gin.Context) {
+		handlerCalled = true
+	})
+
+	req := httptest.NewRequest("GET", "/premium/data", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Errorf("Expected status 402, got %d", w.Code)
+	}
+	if handlerCalled {
+		t.Error("Expected handler not to be called")
+	}
+
+	header := w.Header().Get("PAYMENT-REQUIRED")
+	if header == "" {
+		t.Fatal("Expected PAYMENT-REQUIRED header")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		t.Fatalf("Failed to decode PAYMENT-REQUIRED header: %v", err)
+	}
+	var paymentRequired types.PaymentRequired
+	if err := json.Unmarshal(decoded, &paymentRequired); err != nil {
+		t.Fatalf("Failed to parse payment requirements: %v", err)
+	}
+	if len(paymentRequired.Accepts) != 1 || paymentRequired.Accepts[0].PayTo != "0xtest" {
+		t.Errorf("Unexpected payment requirements: %+v", paymentRequired.Accepts)
+	}
+
+	// Routes outside the group stay free
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/free", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200 outside the group, got %d", w.Code)
+	}
+}
+
+func TestRequirePayment_SettlesBeforeHandler(t *testing.T) {
+	settleCalled := false
+	mockClient := &mockFacilitatorClient{
+		settleFunc: func(ctx context.Context, payloadBytes []byte, requirementsBytes []byte) (*x402.SettleResponse, error) {
+			settleCalled = true
+			return &x402.SettleResponse{
+				Success:     true,
+				Transaction: "0xtx",
+				Network:     "eip155:1",
+				Payer:       "0xpayer",
+			}, nil
+		},
+	}
+
+	router := createRequirePaymentRouter(mockClient, func(c *gin.Context) {
+		if !settleCalled {
+			t.Error("Expected settlement before the handler")
+		}
+		settleResponse, ok := GetSettleResponse(c)
+		if !ok {
+			t.Fatal("Expected settlement in context")
+		}
+		c.String(http.StatusOK, settleResponse.Transaction)
+	})
+
+	req := httptest.NewRequest("GET", "/premium/data", nil)
+	req.Header.Set("PAYMENT-SIGNATURE", createPaymentHeader("0xtest"))
+	req.Host = "example.com"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if w.Body.String() != "0xtx" {
+		t.Errorf("Expected handler to read transaction 0xtx, got %s", w.Body.String())
+	}
+	if w.Header().Get("PAYMENT-RESPONSE") == "" {
+		t.Error("Expected PAYMENT-RESPONSE header")
+	}
+}
+
+func TestRequirePayment_Returns402WhenSettlementFails(t *testing.T) {
+	mockClient := &mockFacilitatorClient{
+		settleFunc: func(ctx context.Context, payloadBytes []byte, requirementsBytes []byte) (*x402.SettleResponse, error) {
+			return &x402.SettleResponse{Success: false, ErrorReason: "Insufficient funds"}, nil
+		},
+	}
+
+	handlerCalled := false
+	router := createRequirePaymentRouter(mockClient, func(c *gin.Context) {
+		handlerCalled = true
+	})
+
+	req := httptest.NewRequest("GET", "/premium/data", nil)
+	req.Header.Set("PAYMENT-SIGNATURE", createPaymentHeader("0xtest"))
+	req.Host = "example.com"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Errorf("Expected status 402, got %d", w.Code)
+	}
+	if handlerCalled {
+		t.Error("Expected handler not to be called")
+	}
+}
+
+func TestRequirePayment_PanicsWithoutPayTo(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic without a payment recipient")
+		}
+	}()
+	RequirePayment("$1.00", "", "eip155:1", WithSyncFacilitatorOnStart(false))
+}
+
 // ============================================================================
 // responseCapture Tests
 // ============================================================================
@@ -0,0 +1,134 @@
+/* require_payment.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package gin
+
+import (
+	"context"
+	"time"
+
+	x402 "github.com/coinbase/x402/go"
+	x402http "github.com/coinbase/x402/go/http"
+	"github.com/gin-gonic/gin"
+)
+
+// SettleContextKey is the gin.Context key holding the *x402.SettleResponse
+// of the payment accepted by RequirePayment
+const SettleContextKey = "x402.settle"
+
+// RequirePayment creates middleware requiring an exact payment on every route
+// it is attached to, typically a route group.
+//
+// When asset is empty, price is a money amount (e.g., "$0.01") paid in the
+// network's default asset. Otherwise price is the amount of asset in its
+// smallest unit. The recipient is set with WithPayTo, and schemes and
+// facilitators with the usual middleware options.
+//
+// Requests without a valid payment receive a 402 with the payment requirements.
+// Unlike PaymentMiddleware, a valid payment is verified and settled before the
+// handlers run, so they can read the settlement with GetSettleResponse.
+//
+// Example:
+//
+//	paid := r.Group("/premium")
+//	paid.Use(ginmw.RequirePayment("$0.01", "", "eip155:8453",
+//	    ginmw.WithPayTo("0x123..."),
+//	    ginmw.WithFacilitatorClient(facilitator),
+//	    ginmw.WithScheme("eip155:*", evm.NewExactEvmScheme()),
+//	))
+func RequirePayment(price string, asset string, network x402.Network, opts ...MiddlewareOption) gin.HandlerFunc {
+	config := &MiddlewareConfig{
+		SyncFacilitatorOnStart: true,
+		Timeout:                30 * time.Second,
+	}
+
+	// Apply options
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.PayTo == "" {
+		panic("x402: RequirePayment needs a payment recipient, see WithPayTo")
+	}
+
+	var routePrice x402.Price = price
+	if asset != "" {
+		routePrice = map[string]interface{}{
+			"amount": price,
+			"asset":  asset,
+		}
+	}
+
+	// The middleware guards whole groups, so every route it sees requires payment
+	config.Routes = x402http.RoutesConfig{
+		"*": {
+			Accepts: x402http.PaymentOptions{
+				{
+					Scheme:  "exact",
+					PayTo:   config.PayTo,
+					Price:   routePrice,
+					Network: network,
+				},
+			},
+		},
+	}
+
+	server := newHTTPServer(config)
+
+	return func(c *gin.Context) {
+		reqCtx := x402http.HTTPRequestContext{
+			Adapter: NewGinAdapter(c),
+			Path:    c.Request.URL.Path,
+			Method:  c.Request.Method,
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), config.Timeout)
+		defer cancel()
+
+		result := server.ProcessHTTPRequest(ctx, reqCtx, config.PaywallConfig)
+		switch result.Type {
+		case x402http.ResultNoPaymentRequired:
+			c.Next()
+
+		case x402http.ResultPaymentError:
+			handlePaymentError(c, result.Response, config)
+
+		case x402http.ResultPaymentVerified:
+			settleResult := server.ProcessSettlement(ctx, *result.PaymentPayload, *result.PaymentRequirements)
+			if !settleResult.Success {
+				handleSettlementFailure(c, settleResult, config)
+				c.Abort()
+				return
+			}
+
+			for key, value := range settleResult.Headers {
+				c.Header(key, value)
+			}
+
+			settleResponse := &x402.SettleResponse{
+				Success:     true,
+				Transaction: settleResult.Transaction,
+				Network:     settleResult.Network,
+				Payer:       settleResult.Payer,
+			}
+			c.Set(SettleContextKey, settleResponse)
+			if config.SettlementHandler != nil {
+				config.SettlementHandler(c, settleResponse)
+			}
+
+			c.Next()
+		}
+	}
+}
+
+// GetSettleResponse returns the settlement stored by RequirePayment
+func GetSettleResponse(c *gin.Context) (*x402.SettleResponse, bool) {
+	value, ok := c.Get(SettleContextKey)
+	if !ok {
+		return nil, false
+	}
+	settleResponse, ok := value.(*x402.SettleResponse)
+	return settleResponse, ok
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -30,8 +30,8 @@ type (
 // ============================================================================
 
 // NewClient creates a new HTTP-aware x402 client
-func NewClient(client *x402.X402Client) *x402HTTPClient {
-	return Newx402HTTPClient(client)
+func NewClient(client *x402.X402Client, opts ...HTTPClientOption) *x402HTTPClient {
+	return Newx402HTTPClient(client, opts...)
 }
 
 // NewServer creates a new HTTP resource server
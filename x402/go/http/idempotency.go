@@ -0,0 +1,162 @@
+/* idempotency.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	x402 "github.com/coinbase/x402/go"
+)
+
+// ============================================================================
+// Idempotent Payments
+// ============================================================================
+
+// IdempotencyKeyHeader carries the idempotency key of a paid request, so the
+// server and facilitator can dedupe retries
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// defaultIdempotencyTTL is how long a signed payment is kept when
+// WithIdempotency is given no TTL
+const defaultIdempotencyTTL = 10 * time.Minute
+
+// errReusePayment signals that a payment signed earlier for the same
+// idempotency key is sent instead of signing a new one
+var errReusePayment = errors.New("reusing signed payment")
+
+// signedPayment is a payment signed for an idempotency key until expires
+type signedPayment struct {
+	requirements string
+	payload      []byte
+	expires      time.Time
+}
+
+// idempotencyCache maps idempotency keys to the payment signed for them
+type idempotencyCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]signedPayment
+}
+
+// WithIdempotency sends every request with an IdempotencyKeyHeader and reuses
+// the payment signed for a key when the request is retried with the same key,
+// instead of signing a fresh authorization that could be settled twice.
+//
+// The key is the request's Idempotency-Key header, else RequestOptions'
+// IdempotencyKey, else one generated for the request. Retrying with the same
+// key across calls therefore needs a key set by the caller, see
+// NewIdempotencyKey. A signed payment is kept for ttl, or until the paid
+// request succeeds or permanently fails; transport errors, 5xx, 408 and 429
+// responses keep it for the retry. A ttl of 0 keeps payments for 10 minutes
+func WithIdempotency(ttl time.Duration) HTTPClientOption {
+	return func(c *x402HTTPClient) {
+		if ttl <= 0 {
+			ttl = defaultIdempotencyTTL
+		}
+		c.idempotency = &idempotencyCache{
+			ttl:     ttl,
+			entries: make(map[string]signedPayment),
+		}
+	}
+}
+
+// NewIdempotencyKey returns a random idempotency key for a logical request,
+// to be reused by each of its retries
+func NewIdempotencyKey() (string, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// withIdempotencyKey returns req carrying its idempotency key, and the key
+func withIdempotencyKey(ctx context.Context, req *http.Request) (*http.Request, string, error) {
+	if key := req.Header.Get(IdempotencyKeyHeader); key != "" {
+		return req, key, nil
+	}
+	key := requestOptionsFromContext(ctx).IdempotencyKey
+	if key == "" {
+		var err error
+		if key, err = NewIdempotencyKey(); err != nil {
+			return nil, "", err
+		}
+	}
+	keyed := req.Clone(ctx)
+	keyed.Header.Set(IdempotencyKeyHeader, key)
+	return keyed, key, nil
+}
+
+// requirementsFingerprint identifies what a signed payment pays for
+func requirementsFingerprint(version int, requirements x402.PaymentRequirementsView) string {
+	return fmt.Sprintf("%d|%s|%s|%s|%s|%s", version, requirements.GetScheme(), requirements.GetNetwork(),
+		requirements.GetAsset(), requirements.GetAmount(), requirements.GetPayTo())
+}
+
+// lookup returns the payment signed for key if it pays the same requirements
+func (i *idempotencyCache) lookup(key, requirements string) ([]byte, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	entry, ok := i.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(entry.expires) {
+		delete(i.entries, key)
+		return nil, false
+	}
+	if entry.requirements != requirements {
+		return nil, false
+	}
+	return entry.payload, true
+}
+
+// store records the payment signed for key
+func (i *idempotencyCache) store(key, requirements string, payload []byte) {
+	now := time.Now()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for k, entry := range i.entries {
+		if !now.Before(entry.expires) {
+			delete(i.entries, k)
+		}
+	}
+	i.entries[key] = signedPayment{requirements: requirements, payload: payload, expires: now.Add(i.ttl)}
+}
+
+// forget drops the payment signed for key
+func (i *idempotencyCache) forget(key string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.entries, key)
+}
+
+// settled forgets the payment signed for key unless resp, the answer to the
+// paid request, is worth retrying with it. A failed settlement always drops
+// the payment
+func (i *idempotencyCache) settled(key string, resp *http.Response, settlement *x402.SettleResponse) {
+	if settlement == nil || settlement.Success {
+		if retryableStatus(resp.StatusCode) {
+			return
+		}
+	}
+	i.forget(key)
+}
+
+// retryableStatus reports whether a request answered with status may succeed
+// when sent again
+func retryableStatus(status int) bool {
+	return status >= http.StatusInternalServerError ||
+		status == http.StatusRequestTimeout ||
+		status == http.StatusTooManyRequests
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,152 @@
+/* refund.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	x402 "github.com/coinbase/x402/go"
+)
+
+// ============================================================================
+// Client-initiated Refunds
+// ============================================================================
+
+// RefundSigner signs refund requests with the payer's key
+type RefundSigner interface {
+	// Address returns the address of the payer
+	Address() string
+
+	// SignRefund signs message, the JSON encoding of a RefundRequest without
+	// its signature
+	SignRefund(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// RefundRequest asks the facilitator to refund (part of) a settled payment
+// It is signed by the payer, the facilitator checks the signature against Payer
+type RefundRequest struct {
+	X402Version int          `json:"x402Version"`
+	Network     x402.Network `json:"network"`
+	Transaction string       `json:"transaction"`      // Settlement transaction being refunded
+	Payer       string       `json:"payer"`            // Address of the refund signer
+	Amount      string       `json:"amount,omitempty"` // In atomic units, empty for a full refund
+	Nonce       string       `json:"nonce"`            // Random 32 bytes, hex encoded
+	Timestamp   int64        `json:"timestamp"`        // Unix seconds
+	Signature   string       `json:"signature,omitempty"`
+}
+
+// RefundResponse is the facilitator's answer to a RefundRequest
+type RefundResponse struct {
+	Success     bool         `json:"success"`
+	ErrorReason string       `json:"errorReason,omitempty"`
+	Transaction string       `json:"transaction,omitempty"` // Refund transaction
+	Network     x402.Network `json:"network,omitempty"`
+	Amount      string       `json:"amount,omitempty"` // Amount refunded, in atomic units
+}
+
+// ErrRefundSignerRequired is returned by RequestRefund when the client was
+// created without a FacilitatorConfig.RefundSigner
+var ErrRefundSignerRequired = errors.New("refund signer required")
+
+// RequestRefund asks the facilitator to refund amount of the payment settled
+// in receipt, e.g. when the paid resource was not delivered. A nil amount
+// requests a full refund. The request is signed with the RefundSigner
+func (c *HTTPFacilitatorClient) RequestRefund(ctx context.Context, receipt *x402.SettleResponse, amount *big.Int) (*RefundResponse, error) {
+	if c.refundSigner == nil {
+		return nil, ErrRefundSignerRequired
+	}
+	if receipt == nil || !receipt.Success || receipt.Transaction == "" {
+		return nil, errors.New("refund requires the receipt of a successful settlement")
+	}
+	payer := c.refundSigner.Address()
+	if receipt.Payer != "" && !strings.EqualFold(receipt.Payer, payer) {
+		return nil, fmt.Errorf("receipt payer %s does not match refund signer %s", receipt.Payer, payer)
+	}
+	if amount != nil && amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid refund amount %s", amount)
+	}
+
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	refund := RefundRequest{
+		X402Version: 2,
+		Network:     receipt.Network,
+		Transaction: receipt.Transaction,
+		Payer:       payer,
+		Nonce:       "0x" + hex.EncodeToString(nonce),
+		Timestamp:   time.Now().Unix(),
+	}
+	if amount != nil {
+		refund.Amount = amount.String()
+	}
+
+	message, err := json.Marshal(refund)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal refund request: %w", err)
+	}
+	signature, err := c.refundSigner.SignRefund(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign refund request: %w", err)
+	}
+	refund.Signature = "0x" + hex.EncodeToString(signature)
+
+	body, err := json.Marshal(refund)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal refund request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url+"/refund", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refund request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Refunds are authenticated like settlements
+	if c.authProvider != nil {
+		authHeaders, err := c.authProvider.GetAuthHeaders(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get auth headers: %w", err)
+		}
+		for k, v := range authHeaders.Settle {
+			req.Header.Set(k, v)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("refund request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var refundResponse RefundResponse
+	if err := json.Unmarshal(responseBody, &refundResponse); err != nil {
+		return nil, fmt.Errorf("facilitator refund failed (%d): %s", resp.StatusCode, string(responseBody))
+	}
+	if resp.StatusCode != http.StatusOK {
+		if refundResponse.ErrorReason != "" {
+			return nil, fmt.Errorf("facilitator refund failed (%d): %s", resp.StatusCode, refundResponse.ErrorReason)
+		}
+		return nil, fmt.Errorf("facilitator refund failed (%d): %s", resp.StatusCode, string(responseBody))
+	}
+	return &refundResponse, nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
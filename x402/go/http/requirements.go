@@ -0,0 +1,183 @@
+/* requirements.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	x402 "github.com/coinbase/x402/go"
+	"github.com/coinbase/x402/go/types"
+)
+
+// ============================================================================
+// Payment Requirements
+// ============================================================================
+
+// PaymentRequirements is the payment a server asks for in a 402 challenge,
+// decoded into one stable shape for both protocol versions. It is meant for
+// UIs and logs and marshals to the wire JSON field names
+type PaymentRequirements struct {
+	Scheme            string                 `json:"scheme"`
+	Network           string                 `json:"network"`
+	Asset             string                 `json:"asset"`
+	Amount            string                 `json:"amount"` // In the asset's smallest unit
+	PayTo             string                 `json:"payTo"`
+	Resource          string                 `json:"resource,omitempty"` // URL of the paid resource
+	MaxTimeoutSeconds int                    `json:"maxTimeoutSeconds"`
+	Extra             map[string]interface{} `json:"extra,omitempty"`
+	QuotedAt          time.Time              `json:"quotedAt,omitzero"` // Set on requirements returned by Quote
+}
+
+// newPaymentRequirements converts selected requirements of either version
+func newPaymentRequirements(requirements x402.PaymentRequirementsView, resource string) *PaymentRequirements {
+	return &PaymentRequirements{
+		Scheme:            requirements.GetScheme(),
+		Network:           requirements.GetNetwork(),
+		Asset:             requirements.GetAsset(),
+		Amount:            requirements.GetAmount(),
+		PayTo:             requirements.GetPayTo(),
+		Resource:          resource,
+		MaxTimeoutSeconds: requirements.GetMaxTimeoutSeconds(),
+		Extra:             requirements.GetExtra(),
+	}
+}
+
+// resourceURL returns the URL of a V2 challenge's resource, if any
+func resourceURL(resource *types.ResourceInfo) string {
+	if resource == nil {
+		return ""
+	}
+	return resource.URL
+}
+
+// Quote requests a resource without paying and returns the payment
+// requirements the client would pay, or nil when no payment is required.
+// The response is discarded. With WithQuoteMaxStaleness the quote is
+// remembered and re-checked when the resource is paid
+func (c *x402HTTPClient) Quote(ctx context.Context, req *http.Request) (*PaymentRequirements, error) {
+	state := &fetchState{quoteOnly: true}
+	ctx = context.WithValue(ctx, fetchStateKey{}, state)
+
+	resp, err := c.DoWithPayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPaymentRequired || state.requirements == nil {
+		return nil, nil
+	}
+	quote := state.requirements
+	quote.QuotedAt = time.Now()
+	c.rememberQuote(quoteKey(req), quote)
+	return quote, nil
+}
+
+// quoteKey identifies the resource requested by req among remembered quotes
+func quoteKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+// rememberQuote stores a copy of quote for checkQuote, if quotes expire
+func (c *x402HTTPClient) rememberQuote(key string, quote *PaymentRequirements) {
+	if c.quoteMaxStaleness <= 0 {
+		return
+	}
+	remembered := *quote
+
+	c.quotesMu.Lock()
+	defer c.quotesMu.Unlock()
+	if c.quotes == nil {
+		c.quotes = make(map[string]*PaymentRequirements)
+	}
+	c.quotes[key] = &remembered
+}
+
+// checkQuote consumes the quote remembered for key, if any. A quote older
+// than the max staleness is re-checked against current, the requirements of
+// the challenge just received, which acts as the re-fetched quote
+func (c *x402HTTPClient) checkQuote(key string, current *PaymentRequirements) error {
+	if c.quoteMaxStaleness <= 0 {
+		return nil
+	}
+
+	c.quotesMu.Lock()
+	quote, ok := c.quotes[key]
+	delete(c.quotes, key)
+	c.quotesMu.Unlock()
+
+	if !ok || time.Since(quote.QuotedAt) <= c.quoteMaxStaleness {
+		return nil
+	}
+
+	if quote.Scheme != current.Scheme || x402.ParseNetwork(quote.Network) != x402.ParseNetwork(current.Network) || quote.Asset != current.Asset {
+		return fmt.Errorf("%w: quoted %s %s on %s, now %s %s on %s", ErrQuotePriceMoved,
+			quote.Amount, quote.Asset, quote.Network, current.Amount, current.Asset, current.Network)
+	}
+	if !withinTolerance(quote.Amount, current.Amount, c.priceTolerance) {
+		return fmt.Errorf("%w: quoted %s, now %s", ErrQuotePriceMoved, quote.Amount, current.Amount)
+	}
+	return nil
+}
+
+// withinTolerance reports whether amount differs from quoted by at most
+// tolerance, a fraction of quoted
+func withinTolerance(quoted, amount string, tolerance float64) bool {
+	q, ok := new(big.Rat).SetString(quoted)
+	if !ok {
+		return false
+	}
+	a, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return false
+	}
+
+	diff := new(big.Rat).Sub(a, q)
+	diff.Abs(diff)
+	allowed := new(big.Rat).SetFloat64(tolerance)
+	if allowed == nil {
+		return false
+	}
+	allowed.Mul(allowed, q.Abs(q))
+	return diff.Cmp(allowed) <= 0
+}
+
+// ============================================================================
+// Fetch State
+// ============================================================================
+
+// fetchState collects what happened to a Fetch or Quote in progress
+type fetchState struct {
+	outcome      PaymentOutcome
+	requirements *PaymentRequirements
+	quoteOnly    bool
+	catalog      *CatalogResource // Advertised price checked by FetchFromCatalog
+	fiat         *FiatConversion
+}
+
+// fetchStateKey is the context key under which Fetch and Quote collect their state
+type fetchStateKey struct{}
+
+// recordPaymentOutcome stores the outcome for a Fetch in progress, if any
+func recordPaymentOutcome(ctx context.Context, outcome PaymentOutcome) {
+	if state, ok := ctx.Value(fetchStateKey{}).(*fetchState); ok {
+		state.outcome = outcome
+	}
+}
+
+// recordRequirements stores the selected requirements for a Fetch or Quote in
+// progress and reports whether they must not be paid
+func recordRequirements(ctx context.Context, requirements *PaymentRequirements) (quoteOnly bool) {
+	if state, ok := ctx.Value(fetchStateKey{}).(*fetchState); ok {
+		state.requirements = requirements
+		return state.quoteOnly
+	}
+	return false
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
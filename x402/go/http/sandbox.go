@@ -0,0 +1,115 @@
+/* sandbox.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	x402 "github.com/coinbase/x402/go"
+	"github.com/coinbase/x402/go/types"
+)
+
+// ============================================================================
+// Sandbox Mode
+// ============================================================================
+
+// sandboxTxPrefix starts every sandbox transaction hash, no real transaction
+// hash is expected to start with 8 zero bytes
+const sandboxTxPrefix = "0x0000000000000000"
+
+// NewSandboxClient creates an HTTP client that simulates payments for local
+// development and CI. It accepts the first requirements of any challenge and
+// sends an unsigned sandbox payload, no scheme, key, facilitator or chain is
+// involved. Paid responses without a settlement header get a successful
+// SettleResponse with a deterministic fake transaction hash, see
+// IsSandboxTransaction
+func NewSandboxClient(opts ...HTTPClientOption) *x402HTTPClient {
+	c := Newx402HTTPClient(x402.Newx402Client(), opts...)
+	c.sandbox = true
+	return c
+}
+
+// IsSandboxTransaction reports whether txHash was made up by a sandbox client
+func IsSandboxTransaction(txHash string) bool {
+	return len(txHash) == 66 && strings.HasPrefix(txHash, sandboxTxPrefix)
+}
+
+// sandboxTransaction derives a fake transaction hash from the paid requirements
+func sandboxTransaction(requirements x402.PaymentRequirementsView) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		requirements.GetScheme(),
+		requirements.GetNetwork(),
+		requirements.GetAsset(),
+		requirements.GetAmount(),
+		requirements.GetPayTo(),
+	}, "|")))
+	return sandboxTxPrefix + hex.EncodeToString(sum[:24])
+}
+
+// sandboxSettleResponse is the settlement reported for sandbox payments
+func sandboxSettleResponse(requirements x402.PaymentRequirementsView) x402.SettleResponse {
+	return x402.SettleResponse{
+		Success:     true,
+		Transaction: sandboxTransaction(requirements),
+		Network:     x402.ParseNetwork(requirements.GetNetwork()),
+	}
+}
+
+// sandboxPayloadData is the unsigned payload sent by sandbox clients
+func sandboxPayloadData(requirements x402.PaymentRequirementsView) map[string]interface{} {
+	return map[string]interface{}{
+		"sandbox":     true,
+		"transaction": sandboxTransaction(requirements),
+	}
+}
+
+// selectSandboxRequirements accepts the first requirements of a challenge
+func selectSandboxRequirements[T any](accepts []T) (T, error) {
+	if len(accepts) == 0 {
+		var zero T
+		return zero, fmt.Errorf("cannot fulfill payment requirements: challenge accepts nothing")
+	}
+	return accepts[0], nil
+}
+
+// sandboxPaymentV1 builds the V1 sandbox payload for requirements
+func sandboxPaymentV1(requirements types.PaymentRequirementsV1) types.PaymentPayloadV1 {
+	return types.PaymentPayloadV1{
+		X402Version: 1,
+		Scheme:      requirements.Scheme,
+		Network:     requirements.Network,
+		Payload:     sandboxPayloadData(requirements),
+	}
+}
+
+// sandboxPayment builds the V2 sandbox payload for requirements
+func sandboxPayment(requirements types.PaymentRequirements, required types.PaymentRequired) types.PaymentPayload {
+	return types.PaymentPayload{
+		X402Version: 2,
+		Payload:     sandboxPayloadData(requirements),
+		Accepted:    requirements,
+		Resource:    required.Resource,
+		Extensions:  required.Extensions,
+	}
+}
+
+// setSandboxSettlement reports the sandbox settlement on a successful paid
+// response unless the server already sent a settlement header
+func setSandboxSettlement(resp *http.Response, names PaymentHeaderNames, requirements x402.PaymentRequirementsView) {
+	if resp.StatusCode >= http.StatusBadRequest ||
+		resp.Header.Get(names.PaymentResponse) != "" ||
+		resp.Header.Get("PAYMENT-RESPONSE") != "" || resp.Header.Get("X-PAYMENT-RESPONSE") != "" {
+		return
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	resp.Header.Set(names.PaymentResponse, encodePaymentResponseHeader(sandboxSettleResponse(requirements)))
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
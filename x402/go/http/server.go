@@ -330,11 +330,7 @@ func (s *x402HTTPResourceServer) ProcessHTTPRequest(ctx context.Context, reqCtx
 		requirements[i].Extra["resourceUrl"] = resourceInfo.URL
 	}
 
-	extensions := routeConfig.Extensions
-	// TODO: Add EnrichExtensions method if needed
-	// if extensions != nil && len(extensions) > 0 {
-	// 	extensions = s.EnrichExtensions(extensions, reqCtx)
-	// }
+	extensions := s.EnrichExtensions(routeConfig.Extensions, reqCtx)
 
 	if typedPayload == nil {
 		paymentRequired := s.CreatePaymentRequiredResponse(
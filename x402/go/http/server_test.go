@@ -190,6 +190,105 @@ func TestProcessHTTPRequestPaymentRequired(t *testing.T) {
 	}
 }
 
+// Test extension recording the order it is called in
+type mockServerExtension struct {
+	key    string
+	calls  *[]string
+	enrich func(declaration interface{}, reqCtx HTTPRequestContext) interface{}
+}
+
+func (e *mockServerExtension) Key() string { return e.key }
+
+func (e *mockServerExtension) EnrichDeclaration(declaration interface{}, transportContext interface{}) interface{} {
+	*e.calls = append(*e.calls, e.key)
+	reqCtx, ok := transportContext.(HTTPRequestContext)
+	if !ok {
+		return declaration
+	}
+	return e.enrich(declaration, reqCtx)
+}
+
+func TestProcessHTTPRequestEnrichesExtensions(t *testing.T) {
+	ctx := context.Background()
+
+	routes := RoutesConfig{
+		"GET /api": {
+			Accepts: PaymentOptions{
+				{Scheme: "exact", PayTo: "0xtest", Price: "$1.00", Network: "eip155:1"},
+			},
+			Extensions: map[string]interface{}{
+				"discount": map[string]interface{}{"percent": 10},
+			},
+		},
+	}
+
+	mockClient := &mockFacilitatorClient{
+		supported: func(ctx context.Context) (x402.SupportedResponse, error) {
+			return x402.SupportedResponse{
+				Kinds:   []x402.SupportedKind{{X402Version: 2, Scheme: "exact", Network: "eip155:1"}},
+				Signers: make(map[string][]string),
+			}, nil
+		},
+	}
+
+	server := Newx402HTTPResourceServer(
+		routes,
+		x402.WithFacilitatorClient(mockClient),
+		x402.WithSchemeServer("eip155:1", &mockSchemeServer{scheme: "exact"}),
+	)
+	_ = server.Initialize(ctx)
+
+	var calls []string
+	server.RegisterExtension(&mockServerExtension{key: "receipt", calls: &calls,
+		enrich: func(declaration interface{}, reqCtx HTTPRequestContext) interface{} {
+			return map[string]interface{}{"url": "http://example.com/receipts" + reqCtx.Path}
+		},
+	})
+	server.RegisterExtension(&mockServerExtension{key: "discount", calls: &calls,
+		enrich: func(declaration interface{}, reqCtx HTTPRequestContext) interface{} {
+			enriched := map[string]interface{}{"applied": true}
+			for key, value := range declaration.(map[string]interface{}) {
+				enriched[key] = value
+			}
+			return enriched
+		},
+	})
+
+	reqCtx := HTTPRequestContext{
+		Adapter: &mockHTTPAdapter{method: "GET", path: "/api", url: "http://example.com/api", accept: "application/json"},
+		Path:    "/api",
+		Method:  "GET",
+	}
+	result := server.ProcessHTTPRequest(ctx, reqCtx, nil)
+	if result.Response == nil || result.Response.Status != 402 {
+		t.Fatalf("Expected a 402 response, got %+v", result.Response)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(result.Response.Headers["PAYMENT-REQUIRED"])
+	if err != nil {
+		t.Fatalf("Failed to decode PAYMENT-REQUIRED header: %v", err)
+	}
+	var paymentRequired types.PaymentRequired
+	if err := json.Unmarshal(decoded, &paymentRequired); err != nil {
+		t.Fatalf("Failed to unmarshal PAYMENT-REQUIRED header: %v", err)
+	}
+
+	receipt, ok := paymentRequired.Extensions["receipt"].(map[string]interface{})
+	if !ok || receipt["url"] != "http://example.com/receipts/api" {
+		t.Errorf("Expected the receipt extension to be declared, got %v", paymentRequired.Extensions["receipt"])
+	}
+	discount, ok := paymentRequired.Extensions["discount"].(map[string]interface{})
+	if !ok || discount["applied"] != true || discount["percent"] != float64(10) {
+		t.Errorf("Expected the discount declaration to be enriched, got %v", paymentRequired.Extensions["discount"])
+	}
+	if strings.Join(calls, ",") != "discount,receipt" {
+		t.Errorf("Expected extensions to be enriched in key order, got %v", calls)
+	}
+	if _, ok := routes["GET /api"].Extensions["receipt"]; ok {
+		t.Error("Expected the route declarations not to be modified")
+	}
+}
+
 func TestProcessHTTPRequestWithBrowser(t *testing.T) {
 	ctx := context.Background()
 
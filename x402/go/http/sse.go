@@ -0,0 +1,64 @@
+// ucm:0x6E696368:nich
+
+package http
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// ============================================================================
+// Server-Sent Events
+// ============================================================================
+
+// sseEvent is a single event read from a text/event-stream
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readSSE reads events from r until it is exhausted or fn returns false
+func readSSE(r io.Reader, fn func(sseEvent) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var event sseEvent
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line dispatches the buffered event
+		if line == "" {
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if !fn(event) {
+					return nil
+				}
+			}
+			event, data = sseEvent{}, nil
+			continue
+		}
+
+		// Lines starting with a colon are comments
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
+
+
+/* ucm:n1ch2abfa956 */
\ No newline at end of file
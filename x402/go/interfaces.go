@@ -176,4 +176,16 @@ type FacilitatorClient interface {
 }
 
 
+// ============================================================================
+// ChainClient Interface (On-chain Confirmation)
+// ============================================================================
+
+// ChainClient reads transaction state directly from a chain, independently of
+// the facilitator, e.g. through an RPC node
+type ChainClient interface {
+	// TransactionConfirmations returns the number of confirmations of a transaction,
+	// 0 while it is not mined yet
+	TransactionConfirmations(ctx context.Context, txHash string) (uint64, error)
+}
+
 /* universal-crypto-mcp © @nichxbt */
\ No newline at end of file
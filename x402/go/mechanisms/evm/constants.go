@@ -10,6 +10,12 @@ const (
 	// Scheme identifier
 	SchemeExact = "exact"
 
+	// Scheme identifier for variable amount payments up to a signed cap
+	SchemeUpto = "upto"
+
+	// Canonical Permit2 contract address, the same on every EVM chain
+	Permit2Address = "0x000000000022D473030F116dDEE9F6B43aC78BA3"
+
 	// Default token decimals for USDC
 	DefaultDecimals = 6
 
@@ -11,6 +11,8 @@
 package evm
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/big"
 
@@ -46,13 +48,8 @@ func HashTypedData(
 	typedData := apitypes.TypedData{
 		Types:       make(apitypes.Types),
 		PrimaryType: primaryType,
-		Domain: apitypes.TypedDataDomain{
-			Name:              domain.Name,
-			Version:           domain.Version,
-			ChainId:           (*math.HexOrDecimal256)(domain.ChainID),
-			VerifyingContract: domain.VerifyingContract,
-		},
-		Message: message,
+		Domain:      toAPIDomain(domain),
+		Message:     message,
 	}
 
 	// Convert field types
@@ -69,11 +66,11 @@ func HashTypedData(
 
 	// Add EIP712Domain type if not present
 	if _, exists := typedData.Types["EIP712Domain"]; !exists {
-		typedData.Types["EIP712Domain"] = []apitypes.Type{
-			{Name: "name", Type: "string"},
-			{Name: "version", Type: "string"},
-			{Name: "chainId", Type: "uint256"},
-			{Name: "verifyingContract", Type: "address"},
+		for _, field := range DomainFields(domain) {
+			typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{
+				Name: field.Name,
+				Type: field.Type,
+			})
 		}
 	}
 
@@ -99,6 +96,52 @@ func HashTypedData(
 	return digest, nil
 }
 
+// DomainFields returns the EIP712Domain type definition for domain
+// The salt field is only part of the domain when domain.Salt is set
+func DomainFields(domain TypedDataDomain) []TypedDataField {
+	fields := []TypedDataField{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	}
+	if domain.Salt != "" {
+		fields = append(fields, TypedDataField{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
+
+// HashDomain returns the EIP-712 domain separator of domain
+func HashDomain(domain TypedDataDomain) ([]byte, error) {
+	typedData := apitypes.TypedData{
+		Types:  apitypes.Types{},
+		Domain: toAPIDomain(domain),
+	}
+	for _, field := range DomainFields(domain) {
+		typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{
+			Name: field.Name,
+			Type: field.Type,
+		})
+	}
+
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash domain: %w", err)
+	}
+	return domainSeparator, nil
+}
+
+// toAPIDomain converts domain to its go-ethereum representation
+func toAPIDomain(domain TypedDataDomain) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              domain.Name,
+		Version:           domain.Version,
+		ChainId:           (*math.HexOrDecimal256)(domain.ChainID),
+		VerifyingContract: domain.VerifyingContract,
+		Salt:              domain.Salt,
+	}
+}
+
 // HashEIP3009Authorization hashes a TransferWithAuthorization message for EIP-3009
 //
 // This is a convenience function that wraps HashTypedData with the specific
@@ -172,5 +215,44 @@ func HashEIP3009Authorization(
 	return HashTypedData(domain, types, "TransferWithAuthorization", message)
 }
 
+// Requirements Extra keys customizing the signed EIP-712 data
+const (
+	// ExtraSalt is the bytes32 hex salt of the token's EIP-712 domain
+	ExtraSalt = "salt"
+	// ExtraMessageExtensions lists extra fields of the signed message
+	ExtraMessageExtensions = "messageExtensions"
+)
+
+// GetMessageExtensions returns the message extensions advertised in requirements Extra
+// Returns nil when none are advertised. Integer values should be decimal or hex strings
+func GetMessageExtensions(extra map[string]interface{}) ([]MessageExtension, error) {
+	raw, ok := extra[ExtraMessageExtensions]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	// Extra may hold decoded JSON or typed values, normalize through JSON
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message extensions: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var extensions []MessageExtension
+	if err := decoder.Decode(&extensions); err != nil {
+		return nil, fmt.Errorf("invalid message extensions: %w", err)
+	}
+	for i, ext := range extensions {
+		if ext.Name == "" || ext.Type == "" {
+			return nil, fmt.Errorf("invalid message extension %d: name and type are required", i)
+		}
+		// EIP-712 encoding parses integers from strings, not JSON numbers
+		if n, ok := ext.Value.(json.Number); ok {
+			extensions[i].Value = n.String()
+		}
+	}
+	return extensions, nil
+}
+
 
 /* EOF - nirholas | bmljaHhidA== */
\ No newline at end of file
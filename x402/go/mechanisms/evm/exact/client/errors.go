@@ -11,6 +11,8 @@ package client
 const (
 	ErrInvalidAmount             = "invalid_exact_evm_client_amount"
 	ErrFailedToSignAuthorization = "invalid_exact_evm_client_failed_to_sign_authorization"
+	ErrInvalidSplits             = "invalid_exact_evm_client_splits"
+	ErrInvalidMessageExtensions  = "invalid_exact_evm_client_message_extensions"
 )
 
 
@@ -20,12 +20,19 @@ import (
 // ExactEvmScheme implements the SchemeNetworkClient interface for EVM exact payments (V2)
 type ExactEvmScheme struct {
 	signer evm.ClientEvmSigner
+	config *evm.ClientConfig // Optional EIP-712 domain salt and message extensions
 }
 
 // NewExactEvmScheme creates a new ExactEvmScheme
-func NewExactEvmScheme(signer evm.ClientEvmSigner) *ExactEvmScheme {
+// Config is optional - if not provided, the standard EIP-3009 domain and message are signed
+func NewExactEvmScheme(signer evm.ClientEvmSigner, config ...*evm.ClientConfig) *ExactEvmScheme {
+	var cfg *evm.ClientConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
 	return &ExactEvmScheme{
 		signer: signer,
+		config: cfg,
 	}
 }
 
@@ -59,53 +66,109 @@ func (c *ExactEvmScheme) CreatePaymentPayload(
 		return types.PaymentPayload{}, fmt.Errorf(ErrInvalidAmount+": %s", requirements.Amount)
 	}
 
-	// Create nonce
-	nonce, err := evm.CreateNonce()
-	if err != nil {
-		return types.PaymentPayload{}, err
-	}
-
 	// V2 specific: No buffer on validAfter (can use immediately)
 	validAfter, validBefore := evm.CreateValidityWindow(time.Hour)
 
 	// Extract extra fields for EIP-3009
-	tokenName := assetInfo.Name
-	tokenVersion := assetInfo.Version
+	domain := evm.TypedDataDomain{
+		Name:              assetInfo.Name,
+		Version:           assetInfo.Version,
+		ChainID:           chainID,
+		VerifyingContract: assetInfo.Address,
+	}
+	var extensions []evm.MessageExtension
+	if c.config != nil {
+		domain.Salt = c.config.DomainSalt
+		extensions = c.config.MessageExtensions
+	}
 	if requirements.Extra != nil {
 		if name, ok := requirements.Extra["name"].(string); ok {
-			tokenName = name
+			domain.Name = name
 		}
 		if ver, ok := requirements.Extra["version"].(string); ok {
-			tokenVersion = ver
+			domain.Version = ver
+		}
+		if salt, ok := requirements.Extra[evm.ExtraSalt].(string); ok {
+			domain.Salt = salt
+		}
+		advertised, err := evm.GetMessageExtensions(requirements.Extra)
+		if err != nil {
+			return types.PaymentPayload{}, fmt.Errorf(ErrInvalidMessageExtensions+": %w", err)
 		}
+		if advertised != nil {
+			extensions = advertised
+		}
+	}
+
+	// Split payments sign one authorization per leg
+	splits, err := evm.GetPaymentSplits(requirements.Extra, value)
+	if err != nil {
+		return types.PaymentPayload{}, fmt.Errorf(ErrInvalidSplits+": %w", err)
+	}
+	if len(splits) > 0 {
+		splitPayload := &evm.ExactEIP3009SplitPayload{Splits: make([]evm.ExactEIP3009Payload, 0, len(splits))}
+		for _, split := range splits {
+			leg, err := c.signLeg(ctx, split.PayTo, split.Amount, validAfter, validBefore, domain, extensions)
+			if err != nil {
+				return types.PaymentPayload{}, err
+			}
+			splitPayload.Splits = append(splitPayload.Splits, *leg)
+		}
+
+		return types.PaymentPayload{
+			X402Version: 2,
+			Payload:     splitPayload.ToMap(),
+		}, nil
+	}
+
+	evmPayload, err := c.signLeg(ctx, requirements.PayTo, value.String(), validAfter, validBefore, domain, extensions)
+	if err != nil {
+		return types.PaymentPayload{}, err
+	}
+
+	// Return partial V2 payload (core will add accepted, resource, extensions)
+	return types.PaymentPayload{
+		X402Version: 2,
+		Payload:     evmPayload.ToMap(),
+	}, nil
+}
+
+// signLeg creates and signs the EIP-3009 authorization paying value to payTo
+func (c *ExactEvmScheme) signLeg(
+	ctx context.Context,
+	payTo string,
+	value string,
+	validAfter *big.Int,
+	validBefore *big.Int,
+	domain evm.TypedDataDomain,
+	extensions []evm.MessageExtension,
+) (*evm.ExactEIP3009Payload, error) {
+	// Create nonce
+	nonce, err := evm.CreateNonce()
+	if err != nil {
+		return nil, err
 	}
 
 	// Create authorization
 	authorization := evm.ExactEIP3009Authorization{
 		From:        c.signer.Address(),
-		To:          requirements.PayTo,
-		Value:       value.String(),
+		To:          payTo,
+		Value:       value,
 		ValidAfter:  validAfter.String(),
 		ValidBefore: validBefore.String(),
 		Nonce:       nonce,
 	}
 
 	// Sign the authorization
-	signature, err := c.signAuthorization(ctx, authorization, chainID, assetInfo.Address, tokenName, tokenVersion)
+	signature, err := c.signAuthorization(ctx, authorization, domain, extensions)
 	if err != nil {
-		return types.PaymentPayload{}, fmt.Errorf(ErrFailedToSignAuthorization+": %w", err)
+		return nil, fmt.Errorf(ErrFailedToSignAuthorization+": %w", err)
 	}
 
 	// Create EVM payload
-	evmPayload := &evm.ExactEIP3009Payload{
+	return &evm.ExactEIP3009Payload{
 		Signature:     evm.BytesToHex(signature),
 		Authorization: authorization,
-	}
-
-	// Return partial V2 payload (core will add accepted, resource, extensions)
-	return types.PaymentPayload{
-		X402Version: 2,
-		Payload:     evmPayload.ToMap(),
 	}, nil
 }
 
@@ -113,27 +176,12 @@ func (c *ExactEvmScheme) CreatePaymentPayload(
 func (c *ExactEvmScheme) signAuthorization(
 	ctx context.Context,
 	authorization evm.ExactEIP3009Authorization,
-	chainID *big.Int,
-	verifyingContract string,
-	tokenName string,
-	tokenVersion string,
+	domain evm.TypedDataDomain,
+	extensions []evm.MessageExtension,
 ) ([]byte, error) {
-	// Create EIP-712 domain
-	domain := evm.TypedDataDomain{
-		Name:              tokenName,
-		Version:           tokenVersion,
-		ChainID:           chainID,
-		VerifyingContract: verifyingContract,
-	}
-
 	// Define EIP-712 types
 	types := map[string][]evm.TypedDataField{
-		"EIP712Domain": {
-			{Name: "name", Type: "string"},
-			{Name: "version", Type: "string"},
-			{Name: "chainId", Type: "uint256"},
-			{Name: "verifyingContract", Type: "address"},
-		},
+		"EIP712Domain": evm.DomainFields(domain),
 		"TransferWithAuthorization": {
 			{Name: "from", Type: "address"},
 			{Name: "to", Type: "address"},
@@ -160,6 +208,12 @@ func (c *ExactEvmScheme) signAuthorization(
 		"nonce":       nonceBytes,
 	}
 
+	// Append token specific message fields
+	for _, ext := range extensions {
+		types["TransferWithAuthorization"] = append(types["TransferWithAuthorization"], evm.TypedDataField{Name: ext.Name, Type: ext.Type})
+		message[ext.Name] = ext.Value
+	}
+
 	// Sign the typed data
 	return c.signer.SignTypedData(ctx, domain, types, "TransferWithAuthorization", message)
 }
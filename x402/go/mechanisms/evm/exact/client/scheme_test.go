@@ -0,0 +1,298 @@
+/**
+ * @file scheme_test.go
+ * @author nirholas
+ * @copyright (c) 2026 nirholas
+ * @license MIT
+ * @repository universal-crypto-mcp
+ * @version 0.4.14.3
+ * @checksum 1493814938
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/x402/go/mechanisms/evm"
+	"github.com/coinbase/x402/go/types"
+)
+
+type mockEvmSigner struct {
+	address string
+	signed  []map[string]interface{}
+	digests [][]byte
+}
+
+func (m *mockEvmSigner) Address() string {
+	return m.address
+}
+
+func (m *mockEvmSigner) SignTypedData(ctx context.Context, domain evm.TypedDataDomain, types map[string][]evm.TypedDataField, primaryType string, message map[string]interface{}) ([]byte, error) {
+	m.signed = append(m.signed, message)
+	digest, err := evm.HashTypedData(domain, types, primaryType, message)
+	if err != nil {
+		return nil, err
+	}
+	m.digests = append(m.digests, digest)
+	return make([]byte, 65), nil
+}
+
+func TestCreatePaymentPayloadSplits(t *testing.T) {
+	signer := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	scheme := NewExactEvmScheme(signer)
+
+	requirements := types.PaymentRequirements{
+		Scheme:  evm.SchemeExact,
+		Network: "eip155:84532",
+		Asset:   "0x036CbD53842c5426634e7929541eC2318f3dCF7e",
+		Amount:  "1000000",
+		PayTo:   "0x2222222222222222222222222222222222222222",
+		Extra: map[string]interface{}{
+			"splits": []interface{}{
+				map[string]interface{}{"payTo": "0x2222222222222222222222222222222222222222", "amount": "900000"},
+				map[string]interface{}{"payTo": "0x3333333333333333333333333333333333333333", "amount": "100000"},
+			},
+		},
+	}
+
+	payload, err := scheme.CreatePaymentPayload(context.Background(), requirements)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(signer.signed) != 2 {
+		t.Fatalf("Expected 2 signed authorizations, got %d", len(signer.signed))
+	}
+
+	// Round-trip through JSON as the payload is sent over the wire
+	data, err := json.Marshal(payload.Payload)
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %v", err)
+	}
+	if !evm.IsSplitPayload(decoded) {
+		t.Fatal("Expected a split payload")
+	}
+
+	splitPayload, err := evm.SplitPayloadFromMap(decoded)
+	if err != nil {
+		t.Fatalf("Failed to parse split payload: %v", err)
+	}
+	if len(splitPayload.Splits) != 2 {
+		t.Fatalf("Expected 2 legs, got %d", len(splitPayload.Splits))
+	}
+
+	expected := []evm.PaymentSplit{
+		{PayTo: "0x2222222222222222222222222222222222222222", Amount: "900000"},
+		{PayTo: "0x3333333333333333333333333333333333333333", Amount: "100000"},
+	}
+	for i, leg := range splitPayload.Splits {
+		if leg.Authorization.To != expected[i].PayTo {
+			t.Errorf("Leg %d: expected to %s, got %s", i, expected[i].PayTo, leg.Authorization.To)
+		}
+		if leg.Authorization.Value != expected[i].Amount {
+			t.Errorf("Leg %d: expected value %s, got %s", i, expected[i].Amount, leg.Authorization.Value)
+		}
+		if leg.Signature == "" {
+			t.Errorf("Leg %d: expected a signature", i)
+		}
+	}
+	if splitPayload.Splits[0].Authorization.Nonce == splitPayload.Splits[1].Authorization.Nonce {
+		t.Error("Expected each leg to use its own nonce")
+	}
+
+	total, err := splitPayload.Total()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total.Cmp(big.NewInt(1000000)) != 0 {
+		t.Errorf("Expected legs to sum to 1000000, got %s", total)
+	}
+}
+
+func TestCreatePaymentPayloadSplitsMismatch(t *testing.T) {
+	scheme := NewExactEvmScheme(&mockEvmSigner{address: "0x1111111111111111111111111111111111111111"})
+
+	requirements := types.PaymentRequirements{
+		Scheme:  evm.SchemeExact,
+		Network: "eip155:84532",
+		Asset:   "0x036CbD53842c5426634e7929541eC2318f3dCF7e",
+		Amount:  "1000000",
+		PayTo:   "0x2222222222222222222222222222222222222222",
+		Extra: map[string]interface{}{
+			"splits": []interface{}{
+				map[string]interface{}{"payTo": "0x2222222222222222222222222222222222222222", "amount": "900000"},
+				map[string]interface{}{"payTo": "0x3333333333333333333333333333333333333333", "amount": "50000"},
+			},
+		},
+	}
+
+	if _, err := scheme.CreatePaymentPayload(context.Background(), requirements); err == nil {
+		t.Fatal("Expected error when split amounts do not sum to the total")
+	}
+}
+
+func TestCreatePaymentPayloadDomainSalt(t *testing.T) {
+	const salt = "0x000000000000000000000000000000000000000000000000000000000000abcd"
+	requirements := types.PaymentRequirements{
+		Scheme:  evm.SchemeExact,
+		Network: "eip155:84532",
+		Asset:   "0x036CbD53842c5426634e7929541eC2318f3dCF7e",
+		Amount:  "1000000",
+		PayTo:   "0x2222222222222222222222222222222222222222",
+	}
+
+	plain := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	if _, err := NewExactEvmScheme(plain).CreatePaymentPayload(context.Background(), requirements); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	salted := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	scheme := NewExactEvmScheme(salted, &evm.ClientConfig{DomainSalt: salt})
+	if _, err := scheme.CreatePaymentPayload(context.Background(), requirements); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plain.digests) != 1 || len(salted.digests) != 1 {
+		t.Fatalf("Expected one digest per signer, got %d and %d", len(plain.digests), len(salted.digests))
+	}
+
+	// The digest must change with the salt even with an identical message
+	salted.signed[0]["nonce"] = plain.signed[0]["nonce"]
+	salted.signed[0]["validAfter"] = plain.signed[0]["validAfter"]
+	salted.signed[0]["validBefore"] = plain.signed[0]["validBefore"]
+	domain := evm.TypedDataDomain{
+		Name:              "USDC",
+		Version:           "2",
+		ChainID:           big.NewInt(84532),
+		VerifyingContract: requirements.Asset,
+	}
+	saltedDomain := domain
+	saltedDomain.Salt = salt
+	transferTypes := map[string][]evm.TypedDataField{
+		"TransferWithAuthorization": {
+			{Name: "from", Type: "address"},
+			{Name: "to", Type: "address"},
+			{Name: "value", Type: "uint256"},
+			{Name: "validAfter", Type: "uint256"},
+			{Name: "validBefore", Type: "uint256"},
+			{Name: "nonce", Type: "bytes32"},
+		},
+	}
+	plainDigest, err := evm.HashTypedData(domain, transferTypes, "TransferWithAuthorization", plain.signed[0])
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	saltedDigest, err := evm.HashTypedData(saltedDomain, transferTypes, "TransferWithAuthorization", salted.signed[0])
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	if string(plainDigest) == string(saltedDigest) {
+		t.Error("Expected the salt to change the signing digest")
+	}
+
+	// The salt is part of the domain separator
+	plainSeparator, err := evm.HashDomain(domain)
+	if err != nil {
+		t.Fatalf("Failed to hash domain: %v", err)
+	}
+	saltedSeparator, err := evm.HashDomain(saltedDomain)
+	if err != nil {
+		t.Fatalf("Failed to hash domain: %v", err)
+	}
+	if string(plainSeparator) == string(saltedSeparator) {
+		t.Error("Expected the salt to change the domain separator")
+	}
+	fields := evm.DomainFields(saltedDomain)
+	if last := fields[len(fields)-1]; last.Name != "salt" || last.Type != "bytes32" {
+		t.Errorf("Expected salt as the last domain field, got %+v", last)
+	}
+
+	// A salt advertised in the challenge is used as well
+	advertised := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	requirements.Extra = map[string]interface{}{evm.ExtraSalt: salt}
+	if _, err := NewExactEvmScheme(advertised).CreatePaymentPayload(context.Background(), requirements); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	advertised.signed[0]["nonce"] = plain.signed[0]["nonce"]
+	advertised.signed[0]["validAfter"] = plain.signed[0]["validAfter"]
+	advertised.signed[0]["validBefore"] = plain.signed[0]["validBefore"]
+	advertisedDigest, err := evm.HashTypedData(saltedDomain, transferTypes, "TransferWithAuthorization", advertised.signed[0])
+	if err != nil {
+		t.Fatalf("Failed to hash: %v", err)
+	}
+	if string(advertisedDigest) != string(saltedDigest) {
+		t.Error("Expected the advertised salt to be signed")
+	}
+}
+
+func TestCreatePaymentPayloadMessageExtensions(t *testing.T) {
+	signer := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	requirements := types.PaymentRequirements{
+		Scheme:  evm.SchemeExact,
+		Network: "eip155:84532",
+		Asset:   "0x036CbD53842c5426634e7929541eC2318f3dCF7e",
+		Amount:  "1000000",
+		PayTo:   "0x2222222222222222222222222222222222222222",
+		Extra: map[string]interface{}{
+			evm.ExtraMessageExtensions: []interface{}{
+				map[string]interface{}{"name": "memo", "type": "uint256", "value": json.Number("42")},
+			},
+		},
+	}
+
+	if _, err := NewExactEvmScheme(signer).CreatePaymentPayload(context.Background(), requirements); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := signer.signed[0]["memo"]; got != "42" {
+		t.Errorf("Expected memo 42 in the signed message, got %v", got)
+	}
+
+	requirements.Extra[evm.ExtraMessageExtensions] = []interface{}{map[string]interface{}{"value": "1"}}
+	if _, err := NewExactEvmScheme(signer).CreatePaymentPayload(context.Background(), requirements); err == nil {
+		t.Error("Expected an error for an extension without name and type")
+	}
+}
+
+// failingSigner stands in for a remote signer, e.g. a KMS, that fails with err
+type failingSigner struct {
+	err error
+}
+
+func (f *failingSigner) Address() string {
+	return "0x1111111111111111111111111111111111111111"
+}
+
+func (f *failingSigner) SignTypedData(ctx context.Context, domain evm.TypedDataDomain, types map[string][]evm.TypedDataField, primaryType string, message map[string]interface{}) ([]byte, error) {
+	return nil, f.err
+}
+
+func TestCreatePaymentPayloadWrapsSignerError(t *testing.T) {
+	errBadKey := errors.New("bad key")
+
+	for _, signerErr := range []error{context.DeadlineExceeded, errBadKey} {
+		scheme := NewExactEvmScheme(&failingSigner{err: signerErr})
+		_, err := scheme.CreatePaymentPayload(context.Background(), types.PaymentRequirements{
+			Scheme:  evm.SchemeExact,
+			Network: "eip155:84532",
+			Asset:   "0x036CbD53842c5426634e7929541eC2318f3dCF7e",
+			Amount:  "1000000",
+			PayTo:   "0x2222222222222222222222222222222222222222",
+		})
+		if err == nil || !strings.HasPrefix(err.Error(), ErrFailedToSignAuthorization) {
+			t.Fatalf("Expected %s, got %v", ErrFailedToSignAuthorization, err)
+		}
+		if !errors.Is(err, signerErr) {
+			t.Errorf("Expected the signer error %v to be wrapped, got %v", signerErr, err)
+		}
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -22,13 +22,16 @@ import (
 
 // ExactEvmScheme implements the SchemeNetworkServer interface for EVM exact payments (V2)
 type ExactEvmScheme struct {
-	moneyParsers []x402.MoneyParser
+	moneyParsers  []x402.MoneyParser
+	rounding      evm.RoundingMode
+	assetDecimals map[string]int // normalized asset address -> decimals
 }
 
 // NewExactEvmScheme creates a new ExactEvmScheme
 func NewExactEvmScheme() *ExactEvmScheme {
 	return &ExactEvmScheme{
-		moneyParsers: []x402.MoneyParser{},
+		moneyParsers:  []x402.MoneyParser{},
+		assetDecimals: map[string]int{},
 	}
 }
 
@@ -69,6 +72,48 @@ func (s *ExactEvmScheme) RegisterMoneyParser(parser x402.MoneyParser) *ExactEvmS
 	return s
 }
 
+// SetRoundingMode sets how prices finer than the asset's decimals are rounded
+// to its smallest unit. It must match the rounding the facilitator expects.
+// Defaults to evm.RoundFloor
+//
+// Returns:
+//
+//	The server instance for chaining
+func (s *ExactEvmScheme) SetRoundingMode(mode evm.RoundingMode) *ExactEvmScheme {
+	s.rounding = mode
+	return s
+}
+
+// RegisterAssetDecimals sets the decimals of asset used when converting prices
+// to its smallest unit, overriding the network defaults and the 18 decimals
+// assumed for unknown tokens
+//
+// Returns:
+//
+//	The server instance for chaining
+func (s *ExactEvmScheme) RegisterAssetDecimals(asset string, decimals int) *ExactEvmScheme {
+	s.assetDecimals[evm.NormalizeAddress(asset)] = decimals
+	return s
+}
+
+// assetInfo returns the asset info of asset on network with registered decimals applied
+func (s *ExactEvmScheme) assetInfo(network string, asset string) (*evm.AssetInfo, error) {
+	info, err := evm.GetAssetInfo(network, asset)
+	if err != nil {
+		return nil, err
+	}
+	withDecimals := s.withDecimals(*info)
+	return &withDecimals, nil
+}
+
+// withDecimals returns info with its registered decimals, if any
+func (s *ExactEvmScheme) withDecimals(info evm.AssetInfo) evm.AssetInfo {
+	if decimals, ok := s.assetDecimals[evm.NormalizeAddress(info.Address)]; ok {
+		info.Decimals = decimals
+	}
+	return info
+}
+
 // ParsePrice parses a price string and converts it to an asset amount (V2)
 // If price is already an AssetAmount, returns it directly.
 // If price is Money (string | number), parses to decimal and tries custom parsers.
@@ -182,32 +227,33 @@ func (s *ExactEvmScheme) defaultMoneyConversion(amount float64, network x402.Net
 	if err != nil {
 		return x402.AssetAmount{}, err
 	}
+	assetInfo := s.withDecimals(config.DefaultAsset)
 
 	// Check if amount appears to already be in smallest unit
 	// (e.g., 1500000 for $1.50 USDC is likely already in smallest unit, not $1.5M)
 	oneUnit := float64(1)
-	for i := 0; i < config.DefaultAsset.Decimals; i++ {
+	for i := 0; i < assetInfo.Decimals; i++ {
 		oneUnit *= 10
 	}
 
 	// If amount is >= 1 unit AND is a whole number, it's likely already in smallest unit
 	if amount >= oneUnit && amount == float64(int64(amount)) {
 		return x402.AssetAmount{
-			Asset:  config.DefaultAsset.Address,
+			Asset:  assetInfo.Address,
 			Amount: fmt.Sprintf("%.0f", amount),
 			Extra:  make(map[string]interface{}),
 		}, nil
 	}
 
 	// Convert decimal to smallest unit (e.g., $1.50 -> 1500000 for USDC with 6 decimals)
-	amountStr := fmt.Sprintf("%.6f", amount)
-	parsedAmount, err := evm.ParseAmount(amountStr, config.DefaultAsset.Decimals)
+	amountStr := strconv.FormatFloat(amount, 'f', -1, 64)
+	parsedAmount, err := evm.ParseAmountRounded(amountStr, assetInfo.Decimals, s.rounding)
 	if err != nil {
 		return x402.AssetAmount{}, fmt.Errorf(ErrFailedToConvertAmount+": %w", err)
 	}
 
 	return x402.AssetAmount{
-		Asset:  config.DefaultAsset.Address,
+		Asset:  assetInfo.Address,
 		Amount: parsedAmount.String(),
 		Extra:  make(map[string]interface{}),
 	}, nil
@@ -226,13 +272,13 @@ func (s *ExactEvmScheme) EnhancePaymentRequirements(
 	var assetInfo *evm.AssetInfo
 	var err error
 	if requirements.Asset != "" {
-		assetInfo, err = evm.GetAssetInfo(networkStr, requirements.Asset)
+		assetInfo, err = s.assetInfo(networkStr, requirements.Asset)
 		if err != nil {
 			return requirements, err
 		}
 	} else {
 		// Try to get default asset for this network
-		assetInfo, err = evm.GetAssetInfo(networkStr, "")
+		assetInfo, err = s.assetInfo(networkStr, "")
 		if err != nil {
 			return requirements, fmt.Errorf(ErrNoAssetSpecified+": %w", err)
 		}
@@ -242,7 +288,7 @@ func (s *ExactEvmScheme) EnhancePaymentRequirements(
 	// Ensure amount is in the correct format (smallest unit)
 	if requirements.Amount != "" && strings.Contains(requirements.Amount, ".") {
 		// Convert decimal to smallest unit
-		amount, err := evm.ParseAmount(requirements.Amount, assetInfo.Decimals)
+		amount, err := evm.ParseAmountRounded(requirements.Amount, assetInfo.Decimals, s.rounding)
 		if err != nil {
 			return requirements, fmt.Errorf(ErrFailedToParseAmount+": %w", err)
 		}
@@ -0,0 +1,90 @@
+/* server_rounding_test.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase/x402/go/mechanisms/evm"
+	"github.com/coinbase/x402/go/types"
+)
+
+// TestParsePrice_RoundingMode tests fractional base unit prices under each rounding mode
+func TestParsePrice_RoundingMode(t *testing.T) {
+	tests := []struct {
+		price    string
+		mode     evm.RoundingMode
+		expected string
+	}{
+		// $0.0000015 is 1.5 base units of USDC (6 decimals)
+		{"$0.0000015", evm.RoundFloor, "1"},
+		{"$0.0000015", evm.RoundCeil, "2"},
+		{"$0.0000015", evm.RoundNearest, "2"},
+		{"$0.0000014", evm.RoundNearest, "1"},
+		{"$1.2345671", evm.RoundCeil, "1234568"},
+		// Exact amounts are never rounded
+		{"$1.50", evm.RoundCeil, "1500000"},
+	}
+
+	for _, tt := range tests {
+		server := NewExactEvmScheme().SetRoundingMode(tt.mode)
+		result, err := server.ParsePrice(tt.price, "eip155:8453")
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got %v", tt.price, err)
+		}
+		if result.Amount != tt.expected {
+			t.Errorf("Price %s with mode %d: expected amount %s, got %s", tt.price, tt.mode, tt.expected, result.Amount)
+		}
+	}
+}
+
+// TestEnhancePaymentRequirements_AssetDecimals tests decimal amounts of an asset with registered decimals
+func TestEnhancePaymentRequirements_AssetDecimals(t *testing.T) {
+	const token = "0x1111111111111111111111111111111111111111"
+
+	tests := []struct {
+		mode     evm.RoundingMode
+		expected string
+	}{
+		{evm.RoundFloor, "1234567"},
+		{evm.RoundCeil, "1234568"},
+		{evm.RoundNearest, "1234568"},
+	}
+
+	for _, tt := range tests {
+		server := NewExactEvmScheme().
+			SetRoundingMode(tt.mode).
+			RegisterAssetDecimals(token, 6)
+
+		requirements, err := server.EnhancePaymentRequirements(context.Background(), types.PaymentRequirements{
+			Scheme:  evm.SchemeExact,
+			Network: "eip155:8453",
+			Asset:   token,
+			Amount:  "1.2345678",
+		}, types.SupportedKind{}, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if requirements.Amount != tt.expected {
+			t.Errorf("Mode %d: expected amount %s, got %s", tt.mode, tt.expected, requirements.Amount)
+		}
+	}
+
+	// Without registered decimals the unknown token is assumed to have 18
+	requirements, err := NewExactEvmScheme().EnhancePaymentRequirements(context.Background(), types.PaymentRequirements{
+		Scheme:  evm.SchemeExact,
+		Network: "eip155:8453",
+		Asset:   token,
+		Amount:  "1.5",
+	}, types.SupportedKind{}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requirements.Amount != "1500000000000000000" {
+		t.Errorf("Expected 18 decimals amount, got %s", requirements.Amount)
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,119 @@
+/* splits.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package evm
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// ExtraSplits is the requirements Extra key listing the legs of a split payment
+// Servers only advertise it when their facilitator supports split settlement
+const ExtraSplits = "splits"
+
+// PaymentSplit is one recipient/amount pair of a split payment
+type PaymentSplit struct {
+	PayTo  string `json:"payTo"`  // Ethereum address (hex)
+	Amount string `json:"amount"` // Amount in the smallest unit
+}
+
+// ExactEIP3009SplitPayload holds one signed EIP-3009 authorization per split leg
+// EIP-3009 transfers to a single recipient, so each leg carries its own
+// signature and nonce
+type ExactEIP3009SplitPayload struct {
+	Splits []ExactEIP3009Payload `json:"splits"`
+}
+
+// ToMap converts an ExactEIP3009SplitPayload to a map for JSON marshaling
+func (p *ExactEIP3009SplitPayload) ToMap() map[string]interface{} {
+	splits := make([]interface{}, len(p.Splits))
+	for i := range p.Splits {
+		splits[i] = p.Splits[i].ToMap()
+	}
+	return map[string]interface{}{
+		"splits": splits,
+	}
+}
+
+// Total returns the sum of the values of all legs
+func (p *ExactEIP3009SplitPayload) Total() (*big.Int, error) {
+	total := new(big.Int)
+	for _, leg := range p.Splits {
+		value, ok := new(big.Int).SetString(leg.Authorization.Value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid split value: %s", leg.Authorization.Value)
+		}
+		total.Add(total, value)
+	}
+	return total, nil
+}
+
+// IsSplitPayload reports whether a payload map holds a split payment
+func IsSplitPayload(data map[string]interface{}) bool {
+	_, ok := data["splits"]
+	return ok
+}
+
+// SplitPayloadFromMap creates an ExactEIP3009SplitPayload from a map
+func SplitPayloadFromMap(data map[string]interface{}) (*ExactEIP3009SplitPayload, error) {
+	legs, ok := data["splits"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("split payload has no splits")
+	}
+
+	payload := &ExactEIP3009SplitPayload{Splits: make([]ExactEIP3009Payload, 0, len(legs))}
+	for i, leg := range legs {
+		legMap, ok := leg.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid split leg %d", i)
+		}
+		legPayload, err := PayloadFromMap(legMap)
+		if err != nil {
+			return nil, err
+		}
+		payload.Splits = append(payload.Splits, *legPayload)
+	}
+	return payload, nil
+}
+
+// GetPaymentSplits returns the split legs advertised in requirements Extra
+// Returns nil when the payment is not split. The leg amounts must add up to total
+func GetPaymentSplits(extra map[string]interface{}, total *big.Int) ([]PaymentSplit, error) {
+	raw, ok := extra[ExtraSplits]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	// Extra may hold decoded JSON or typed values, normalize through JSON
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid splits: %w", err)
+	}
+	var splits []PaymentSplit
+	if err := json.Unmarshal(data, &splits); err != nil {
+		return nil, fmt.Errorf("invalid splits: %w", err)
+	}
+	if len(splits) == 0 {
+		return nil, fmt.Errorf("invalid splits: no legs")
+	}
+
+	sum := new(big.Int)
+	for _, split := range splits {
+		if !IsValidAddress(split.PayTo) {
+			return nil, fmt.Errorf("invalid split recipient: %s", split.PayTo)
+		}
+		amount, ok := new(big.Int).SetString(split.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid split amount: %s", split.Amount)
+		}
+		sum.Add(sum, amount)
+	}
+	if sum.Cmp(total) != 0 {
+		return nil, fmt.Errorf("split amounts sum to %s, expected %s", sum, total)
+	}
+	return splits, nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
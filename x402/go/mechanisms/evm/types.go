@@ -78,6 +78,7 @@ type TypedDataDomain struct {
 	Version           string   `json:"version"`
 	ChainID           *big.Int `json:"chainId"`
 	VerifyingContract string   `json:"verifyingContract"`
+	Salt              string   `json:"salt,omitempty"` // Optional bytes32 salt (hex)
 }
 
 // TypedDataField represents a field in EIP-712 typed data
@@ -86,6 +87,21 @@ type TypedDataField struct {
 	Type string `json:"type"`
 }
 
+// MessageExtension is an extra field appended to a signed EIP-712 message
+// Some token deployments extend TransferWithAuthorization with custom fields
+type MessageExtension struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// ClientConfig contains optional client configuration
+// Values advertised in the payment requirements take precedence
+type ClientConfig struct {
+	DomainSalt        string             // EIP-712 domain salt (bytes32 hex)
+	MessageExtensions []MessageExtension // Extra fields appended to the signed message
+}
+
 // TransactionReceipt represents the receipt of a mined transaction
 type TransactionReceipt struct {
 	Status      uint64 `json:"status"`
@@ -0,0 +1,97 @@
+/* upto.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package evm
+
+import (
+	"math/big"
+)
+
+// ExtraSpender is the Requirements Extra key of the address allowed to settle
+// an upto payment, usually the facilitator. Defaults to payTo
+const ExtraSpender = "spender"
+
+// UptoPermit is a Permit2 PermitTransferFrom allowing Spender to transfer up
+// to Amount of Token from the owner, once, before Deadline
+type UptoPermit struct {
+	Token    string `json:"token"`
+	Amount   string `json:"amount"`   // Cap in the token's smallest unit
+	Spender  string `json:"spender"`  // Address allowed to settle
+	Nonce    string `json:"nonce"`    // Unordered Permit2 nonce (decimal)
+	Deadline string `json:"deadline"` // Unix seconds (decimal)
+}
+
+// UptoPayload is the payload of the upto scheme
+type UptoPayload struct {
+	Signature string     `json:"signature,omitempty"`
+	Owner     string     `json:"owner"`
+	Permit    UptoPermit `json:"permit"`
+}
+
+// ToMap converts an UptoPayload to a map for JSON marshaling
+func (p *UptoPayload) ToMap() map[string]interface{} {
+	result := map[string]interface{}{
+		"owner": p.Owner,
+		"permit": map[string]interface{}{
+			"token":    p.Permit.Token,
+			"amount":   p.Permit.Amount,
+			"spender":  p.Permit.Spender,
+			"nonce":    p.Permit.Nonce,
+			"deadline": p.Permit.Deadline,
+		},
+	}
+	if p.Signature != "" {
+		result["signature"] = p.Signature
+	}
+	return result
+}
+
+// Permit2Domain returns the EIP-712 domain of the Permit2 contract on chainID
+func Permit2Domain(chainID *big.Int) TypedDataDomain {
+	return TypedDataDomain{
+		Name:              "Permit2",
+		ChainID:           chainID,
+		VerifyingContract: Permit2Address,
+	}
+}
+
+// Permit2Types returns the EIP-712 types of a Permit2 PermitTransferFrom
+// Permit2's domain has no version field
+func Permit2Types() map[string][]TypedDataField {
+	return map[string][]TypedDataField{
+		"EIP712Domain": {
+			{Name: "name", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+			{Name: "verifyingContract", Type: "address"},
+		},
+		"PermitTransferFrom": {
+			{Name: "permitted", Type: "TokenPermissions"},
+			{Name: "spender", Type: "address"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+		"TokenPermissions": {
+			{Name: "token", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+		},
+	}
+}
+
+// Message returns the PermitTransferFrom message of the permit for EIP-712 signing
+func (p UptoPermit) Message() map[string]interface{} {
+	amount, _ := new(big.Int).SetString(p.Amount, 10)
+	nonce, _ := new(big.Int).SetString(p.Nonce, 10)
+	deadline, _ := new(big.Int).SetString(p.Deadline, 10)
+
+	return map[string]interface{}{
+		"permitted": map[string]interface{}{
+			"token":  p.Token,
+			"amount": amount,
+		},
+		"spender":  p.Spender,
+		"nonce":    nonce,
+		"deadline": deadline,
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,14 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+package client
+
+// Client error constants for the upto EVM scheme (V2)
+const (
+	ErrInvalidAmount             = "invalid_upto_evm_client_amount"
+	ErrAmountExceedsCap          = "invalid_upto_evm_client_amount_exceeds_cap"
+	ErrInvalidSpender            = "invalid_upto_evm_client_spender"
+	ErrFailedToSignAuthorization = "invalid_upto_evm_client_failed_to_sign_authorization"
+)
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,120 @@
+/* scheme.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/coinbase/x402/go/mechanisms/evm"
+	"github.com/coinbase/x402/go/types"
+)
+
+// UptoEvmScheme implements the SchemeNetworkClient interface for EVM upto payments (V2)
+//
+// The requirements amount is the most the server may settle. The client signs
+// a Permit2 transfer capped at that amount, and the server settles the amount
+// actually consumed.
+type UptoEvmScheme struct {
+	signer    evm.ClientEvmSigner
+	maxAmount *big.Int // Largest amount the client authorizes, nil for no limit
+}
+
+// NewUptoEvmScheme creates a new UptoEvmScheme
+// maxAmount, in the asset's smallest unit, caps every authorization. Requirements
+// asking for more are rejected before signing. nil disables the cap
+func NewUptoEvmScheme(signer evm.ClientEvmSigner, maxAmount *big.Int) *UptoEvmScheme {
+	return &UptoEvmScheme{
+		signer:    signer,
+		maxAmount: maxAmount,
+	}
+}
+
+// Scheme returns the scheme identifier
+func (c *UptoEvmScheme) Scheme() string {
+	return evm.SchemeUpto
+}
+
+// CreatePaymentPayload creates a V2 payment payload for the upto scheme
+func (c *UptoEvmScheme) CreatePaymentPayload(
+	ctx context.Context,
+	requirements types.PaymentRequirements,
+) (types.PaymentPayload, error) {
+	networkStr := string(requirements.Network)
+
+	chainID, err := evm.GetEvmChainId(networkStr)
+	if err != nil {
+		return types.PaymentPayload{}, err
+	}
+
+	assetInfo, err := evm.GetAssetInfo(networkStr, requirements.Asset)
+	if err != nil {
+		return types.PaymentPayload{}, err
+	}
+
+	// Requirements.Amount is the maximum settle amount in the smallest unit
+	amount, ok := new(big.Int).SetString(requirements.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return types.PaymentPayload{}, fmt.Errorf(ErrInvalidAmount+": %s", requirements.Amount)
+	}
+	if c.maxAmount != nil && amount.Cmp(c.maxAmount) > 0 {
+		return types.PaymentPayload{}, fmt.Errorf(ErrAmountExceedsCap+": %s > %s", amount, c.maxAmount)
+	}
+
+	spender := requirements.PayTo
+	if s, ok := requirements.Extra[evm.ExtraSpender].(string); ok && s != "" {
+		spender = s
+	}
+	if !evm.IsValidAddress(spender) {
+		return types.PaymentPayload{}, fmt.Errorf(ErrInvalidSpender+": %s", spender)
+	}
+
+	// Permit2 nonces are unordered, any unused 256-bit value works
+	nonceHex, err := evm.CreateNonce()
+	if err != nil {
+		return types.PaymentPayload{}, err
+	}
+	nonceBytes, err := evm.HexToBytes(nonceHex)
+	if err != nil {
+		return types.PaymentPayload{}, err
+	}
+	nonce := new(big.Int).SetBytes(nonceBytes)
+
+	_, deadline := evm.CreateValidityWindow(time.Hour)
+
+	permit := evm.UptoPermit{
+		Token:    assetInfo.Address,
+		Amount:   amount.String(),
+		Spender:  spender,
+		Nonce:    nonce.String(),
+		Deadline: deadline.String(),
+	}
+
+	signature, err := c.signer.SignTypedData(
+		ctx,
+		evm.Permit2Domain(chainID),
+		evm.Permit2Types(),
+		"PermitTransferFrom",
+		permit.Message(),
+	)
+	if err != nil {
+		return types.PaymentPayload{}, fmt.Errorf(ErrFailedToSignAuthorization+": %w", err)
+	}
+
+	uptoPayload := &evm.UptoPayload{
+		Signature: evm.BytesToHex(signature),
+		Owner:     c.signer.Address(),
+		Permit:    permit,
+	}
+
+	// Return partial V2 payload (core will add accepted, resource, extensions)
+	return types.PaymentPayload{
+		X402Version: 2,
+		Payload:     uptoPayload.ToMap(),
+	}, nil
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,114 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+package client
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	x402 "github.com/coinbase/x402/go"
+	"github.com/coinbase/x402/go/mechanisms/evm"
+	exactclient "github.com/coinbase/x402/go/mechanisms/evm/exact/client"
+	"github.com/coinbase/x402/go/types"
+)
+
+type mockEvmSigner struct {
+	address      string
+	primaryTypes []string
+	signed       []map[string]interface{}
+}
+
+func (m *mockEvmSigner) Address() string {
+	return m.address
+}
+
+func (m *mockEvmSigner) SignTypedData(ctx context.Context, domain evm.TypedDataDomain, types map[string][]evm.TypedDataField, primaryType string, message map[string]interface{}) ([]byte, error) {
+	// Hash to check the typed data is well formed
+	if _, err := evm.HashTypedData(domain, types, primaryType, message); err != nil {
+		return nil, err
+	}
+	m.primaryTypes = append(m.primaryTypes, primaryType)
+	m.signed = append(m.signed, message)
+	return make([]byte, 65), nil
+}
+
+func uptoRequirements(amount string) types.PaymentRequirements {
+	return types.PaymentRequirements{
+		Scheme:  evm.SchemeUpto,
+		Network: "eip155:84532",
+		Asset:   "0x036CbD53842c5426634e7929541eC2318f3dCF7e",
+		Amount:  amount,
+		PayTo:   "0x2222222222222222222222222222222222222222",
+		Extra: map[string]interface{}{
+			evm.ExtraSpender: "0x3333333333333333333333333333333333333333",
+		},
+	}
+}
+
+func TestCreatePaymentPayloadAmountExceedsCap(t *testing.T) {
+	signer := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	scheme := NewUptoEvmScheme(signer, big.NewInt(1000000))
+
+	_, err := scheme.CreatePaymentPayload(context.Background(), uptoRequirements("1000001"))
+	if err == nil || !strings.HasPrefix(err.Error(), ErrAmountExceedsCap) {
+		t.Fatalf("Expected %s, got %v", ErrAmountExceedsCap, err)
+	}
+	if len(signer.signed) != 0 {
+		t.Error("Expected nothing to be signed")
+	}
+}
+
+func TestCreatePaymentPayloadPermit(t *testing.T) {
+	signer := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	scheme := NewUptoEvmScheme(signer, big.NewInt(1000000))
+
+	// The cap itself may be authorized
+	payload, err := scheme.CreatePaymentPayload(context.Background(), uptoRequirements("1000000"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(signer.signed) != 1 || signer.primaryTypes[0] != "PermitTransferFrom" {
+		t.Fatalf("Expected a signed PermitTransferFrom, got %v", signer.primaryTypes)
+	}
+
+	permitted := signer.signed[0]["permitted"].(map[string]interface{})
+	if permitted["amount"].(*big.Int).Cmp(big.NewInt(1000000)) != 0 {
+		t.Errorf("Expected permitted amount 1000000, got %v", permitted["amount"])
+	}
+	if signer.signed[0]["spender"] != "0x3333333333333333333333333333333333333333" {
+		t.Errorf("Expected spender from requirements extra, got %v", signer.signed[0]["spender"])
+	}
+
+	permit := payload.Payload["permit"].(map[string]interface{})
+	if permit["amount"] != "1000000" {
+		t.Errorf("Expected payload amount 1000000, got %v", permit["amount"])
+	}
+	if payload.Payload["owner"] != signer.address {
+		t.Errorf("Expected owner %s, got %v", signer.address, payload.Payload["owner"])
+	}
+}
+
+func TestX402ClientRoutesUptoScheme(t *testing.T) {
+	exactSigner := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+	uptoSigner := &mockEvmSigner{address: "0x1111111111111111111111111111111111111111"}
+
+	client := x402.Newx402Client().
+		Register("eip155:*", exactclient.NewExactEvmScheme(exactSigner)).
+		Register("eip155:*", NewUptoEvmScheme(uptoSigner, nil))
+
+	payload, err := client.CreatePaymentPayload(context.Background(), uptoRequirements("500000"), nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(uptoSigner.signed) != 1 || len(exactSigner.signed) != 0 {
+		t.Errorf("Expected the upto client to sign, got upto=%d exact=%d", len(uptoSigner.signed), len(exactSigner.signed))
+	}
+	if payload.Accepted.Scheme != evm.SchemeUpto {
+		t.Errorf("Expected accepted scheme upto, got %s", payload.Accepted.Scheme)
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -17,34 +17,17 @@ import (
 	"math/big"
 	"strings"
 	"time"
+
+	x402 "github.com/coinbase/x402/go"
 )
 
 // GetEvmChainId returns the chain ID for a given network
 func GetEvmChainId(network string) (*big.Int, error) {
-	networkStr := network
-
-	// Normalize network name
-	switch networkStr {
-	case "base", "base-mainnet":
-		networkStr = "eip155:8453"
-	case "base-sepolia":
-		networkStr = "eip155:84532"
-	}
-
-	if config, ok := NetworkConfigs[networkStr]; ok {
-		return config.ChainID, nil
-	}
-
-	// Try to parse from CAIP-2 format (eip155:chainId)
-	if strings.HasPrefix(networkStr, "eip155:") {
-		chainIdStr := strings.TrimPrefix(networkStr, "eip155:")
-		chainId, ok := new(big.Int).SetString(chainIdStr, 10)
-		if ok {
-			return chainId, nil
-		}
+	chainID, err := x402.ParseNetwork(network).ChainID()
+	if err != nil {
+		return nil, fmt.Errorf("unsupported network: %s", network)
 	}
-
-	return nil, fmt.Errorf("unsupported network: %s", network)
+	return chainID, nil
 }
 
 // CreateNonce generates a random 32-byte nonce
@@ -120,6 +103,48 @@ func ParseAmount(amount string, decimals int) (*big.Int, error) {
 	return result, nil
 }
 
+// RoundingMode selects how amounts finer than a token's decimals are rounded to its smallest unit
+type RoundingMode int
+
+const (
+	// RoundFloor drops the excess digits, as ParseAmount does
+	RoundFloor RoundingMode = iota
+	// RoundCeil rounds any excess up to the next smallest unit
+	RoundCeil
+	// RoundNearest rounds to the nearest smallest unit, halves up
+	RoundNearest
+)
+
+// ParseAmountRounded converts a non-negative decimal string amount to the
+// token's smallest unit, rounding digits beyond decimals with mode
+func ParseAmountRounded(amount string, decimals int, mode RoundingMode) (*big.Int, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount format: %s", amount)
+	}
+
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value.Mul(value, new(big.Rat).SetInt(multiplier))
+
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient, nil
+	}
+
+	switch mode {
+	case RoundFloor:
+	case RoundCeil:
+		quotient.Add(quotient, big.NewInt(1))
+	case RoundNearest:
+		if remainder.Lsh(remainder, 1).Cmp(value.Denom()) >= 0 {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	default:
+		return nil, fmt.Errorf("unknown rounding mode: %d", mode)
+	}
+	return quotient, nil
+}
+
 // FormatAmount converts an amount in wei to a decimal string
 func FormatAmount(amount *big.Int, decimals int) string {
 	if amount == nil {
@@ -157,15 +182,8 @@ func FormatAmount(amount *big.Int, decimals int) string {
 //   - NetworkConfig with chain ID (and default asset if configured)
 //   - Error if the network format is invalid
 func GetNetworkConfig(network string) (*NetworkConfig, error) {
-	networkStr := network
-
-	// Normalize network name
-	switch networkStr {
-	case "base", "base-mainnet":
-		networkStr = "eip155:8453"
-	case "base-sepolia":
-		networkStr = "eip155:84532"
-	}
+	// Normalize legacy network names to CAIP-2
+	networkStr := string(x402.ParseNetwork(network))
 
 	// Check if we have a pre-configured network with default asset
 	if config, ok := NetworkConfigs[networkStr]; ok {
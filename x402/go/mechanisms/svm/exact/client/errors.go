@@ -22,6 +22,10 @@ const (
 	ErrFailedToCreateTransaction    = "invalid_exact_solana_client_failed_to_create_transaction"
 	ErrFailedToSignTransaction      = "invalid_exact_solana_client_failed_to_sign_transaction"
 	ErrFailedToEncodeTransaction    = "invalid_exact_solana_client_failed_to_encode_transaction"
+
+	// ErrFailedToSignAuthorization is ErrFailedToSignTransaction under the
+	// name used by the EVM client, so both can be matched alike
+	ErrFailedToSignAuthorization = ErrFailedToSignTransaction
 )
 
 
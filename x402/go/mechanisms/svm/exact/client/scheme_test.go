@@ -0,0 +1,191 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	solana "github.com/gagliardetto/solana-go"
+
+	"github.com/coinbase/x402/go/mechanisms/svm"
+	svmsigners "github.com/coinbase/x402/go/signers/svm"
+	"github.com/coinbase/x402/go/types"
+)
+
+// Known test keypair, derived from the seed 0x01..0x20
+const (
+	testPrivateKeyBase58 = "2Ana1pUpv2ZbMVkwF5FXapYeBEjdxDatLn7nvJkhgTSdZd8hbDHTd21as7EAsg7ypityqfsw2pMQKJcVDVcAEsd"
+	testAddress          = "9C6hybhQ6Aycep9jaUnP6uL9ZYvDjUp1aSkFWPUFJtpj"
+)
+
+// newMockRPC serves the getAccountInfo and getLatestBlockhash calls made
+// while building a payment, returning a 6 decimals Token program mint
+func newMockRPC(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mint := make([]byte, 82)
+	mint[44] = 6 // decimals
+	mint[45] = 1 // is_initialized
+	blockhash := solana.HashFromBytes(make([]byte, 32)).String()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode RPC request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getAccountInfo":
+			result = map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": map[string]interface{}{
+					"data":       []string{base64.StdEncoding.EncodeToString(mint), "base64"},
+					"executable": false,
+					"lamports":   1461600,
+					"owner":      solana.TokenProgramID.String(),
+					"rentEpoch":  0,
+				},
+			}
+		case "getLatestBlockhash":
+			result = map[string]interface{}{
+				"context": map[string]interface{}{"slot": 1},
+				"value": map[string]interface{}{
+					"blockhash":            blockhash,
+					"lastValidBlockHeight": 100,
+				},
+			}
+		default:
+			t.Errorf("Unexpected RPC method %s", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+	}))
+}
+
+func testRequirements(amount string) types.PaymentRequirements {
+	return types.PaymentRequirements{
+		Scheme:  svm.SchemeExact,
+		Network: svm.SolanaDevnetCAIP2,
+		Asset:   svm.USDCDevnetAddress,
+		Amount:  amount,
+		PayTo:   solana.NewWallet().PublicKey().String(),
+		Extra: map[string]interface{}{
+			"feePayer": solana.NewWallet().PublicKey().String(),
+		},
+	}
+}
+
+func TestCreatePaymentPayloadSignature(t *testing.T) {
+	server := newMockRPC(t)
+	defer server.Close()
+
+	signer, err := svmsigners.NewClientSignerFromPrivateKey(testPrivateKeyBase58)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	if signer.Address().String() != testAddress {
+		t.Fatalf("Expected signer address %s, got %s", testAddress, signer.Address())
+	}
+	scheme := NewExactSvmScheme(signer, &svm.ClientConfig{RPCURL: server.URL})
+
+	requirements := testRequirements("1000")
+	payload, err := scheme.CreatePaymentPayload(context.Background(), requirements)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	svmPayload, err := svm.PayloadFromMap(payload.Payload)
+	if err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	tx, err := svm.DecodeTransaction(svmPayload.Transaction)
+	if err != nil {
+		t.Fatalf("Invalid transaction: %v", err)
+	}
+
+	feePayer := solana.MustPublicKeyFromBase58(requirements.Extra["feePayer"].(string))
+	if !tx.Message.AccountKeys[0].Equals(feePayer) {
+		t.Errorf("Expected fee payer %s, got %s", feePayer, tx.Message.AccountKeys[0])
+	}
+
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to encode message: %v", err)
+	}
+	signed := false
+	for i, key := range tx.Message.AccountKeys[:tx.Message.Header.NumRequiredSignatures] {
+		switch {
+		case key.Equals(signer.Address()):
+			if !tx.Signatures[i].Verify(key, message) {
+				t.Error("Client signature does not verify against the message")
+			}
+			signed = true
+		case !tx.Signatures[i].IsZero():
+			t.Errorf("Expected %s to be left unsigned", key)
+		}
+	}
+	if !signed {
+		t.Error("Expected the transaction to be signed by the client")
+	}
+}
+
+func TestCreatePaymentPayloadInvalidAmount(t *testing.T) {
+	server := newMockRPC(t)
+	defer server.Close()
+
+	signer, err := svmsigners.NewClientSignerFromPrivateKey(testPrivateKeyBase58)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	scheme := NewExactSvmScheme(signer, &svm.ClientConfig{RPCURL: server.URL})
+
+	_, err = scheme.CreatePaymentPayload(context.Background(), testRequirements("1.5"))
+	if err == nil || !strings.HasPrefix(err.Error(), ErrInvalidAmount) {
+		t.Errorf("Expected %s, got %v", ErrInvalidAmount, err)
+	}
+}
+
+// failingSigner is a signer whose signatures always fail
+type failingSigner struct {
+	svm.ClientSvmSigner
+}
+
+func (failingSigner) SignTransaction(context.Context, *solana.Transaction) error {
+	return errors.New("signer unavailable")
+}
+
+func TestCreatePaymentPayloadSignFailure(t *testing.T) {
+	server := newMockRPC(t)
+	defer server.Close()
+
+	signer, err := svmsigners.NewClientSignerFromPrivateKey(testPrivateKeyBase58)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	scheme := NewExactSvmScheme(failingSigner{signer}, &svm.ClientConfig{RPCURL: server.URL})
+
+	_, err = scheme.CreatePaymentPayload(context.Background(), testRequirements("1000"))
+	if err == nil || !strings.HasPrefix(err.Error(), ErrFailedToSignAuthorization) {
+		t.Errorf("Expected %s, got %v", ErrFailedToSignAuthorization, err)
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
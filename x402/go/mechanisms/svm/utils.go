@@ -13,6 +13,8 @@ import (
 	bin "github.com/gagliardetto/binary"
 	solana "github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/programs/token"
+
+	x402 "github.com/coinbase/x402/go"
 )
 
 var (
@@ -22,17 +24,8 @@ var (
 
 // NormalizeNetwork converts V1 network names to CAIP-2 format
 func NormalizeNetwork(network string) (string, error) {
-	// If it's already CAIP-2 format (contains ":"), validate it's supported
-	if strings.Contains(network, ":") {
-		if _, ok := NetworkConfigs[network]; ok {
-			return network, nil
-		}
-		return "", fmt.Errorf("unsupported Solana network: %s", network)
-	}
-
-	// Otherwise, it's a V1 network name, convert to CAIP-2
-	caip2Network, ok := V1ToV2NetworkMap[network]
-	if !ok {
+	caip2Network := string(x402.ParseNetwork(network))
+	if _, ok := NetworkConfigs[caip2Network]; !ok {
 		return "", fmt.Errorf("unsupported Solana network: %s", network)
 	}
 
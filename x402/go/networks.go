@@ -0,0 +1,85 @@
+/*
+ * ═══════════════════════════════════════════════════════════════
+ *  universal-crypto-mcp | nicholas
+ *  ID: 14.9.3.8
+ * ═══════════════════════════════════════════════════════════════
+ */
+
+package x402
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ============================================================================
+// Known Networks
+// ============================================================================
+
+// Supported networks in CAIP-2 format
+const (
+	NetworkEthereum        Network = "eip155:1"
+	NetworkEthereumSepolia Network = "eip155:11155111"
+	NetworkBase            Network = "eip155:8453"
+	NetworkBaseSepolia     Network = "eip155:84532"
+	NetworkSolana          Network = "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp"
+	NetworkSolanaDevnet    Network = "solana:EtWTRABZaYq6iMfeYKouRu166VU2xqa1"
+	NetworkSolanaTestnet   Network = "solana:4uhcVJyU9pJkvQyS88uRDiswHXSCkY3z"
+)
+
+// legacyNetworkNames maps V1 network names to their CAIP-2 identifiers
+var legacyNetworkNames = map[string]Network{
+	"ethereum":         NetworkEthereum,
+	"ethereum-sepolia": NetworkEthereumSepolia,
+	"sepolia":          NetworkEthereumSepolia,
+	"base":             NetworkBase,
+	"base-mainnet":     NetworkBase,
+	"base-sepolia":     NetworkBaseSepolia,
+	"solana":           NetworkSolana,
+	"solana-devnet":    NetworkSolanaDevnet,
+	"solana-testnet":   NetworkSolanaTestnet,
+}
+
+// testnets lists the known test networks
+var testnets = map[Network]bool{
+	NetworkEthereumSepolia: true,
+	NetworkBaseSepolia:     true,
+	NetworkSolanaDevnet:    true,
+	NetworkSolanaTestnet:   true,
+}
+
+// ParseNetwork parses a network string into Network type
+// Legacy V1 names (e.g. "base-sepolia") are converted to CAIP-2, anything
+// else is returned unchanged
+func ParseNetwork(s string) Network {
+	if network, ok := legacyNetworkNames[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return network
+	}
+	return Network(s)
+}
+
+// ChainID returns the EVM chain ID of an eip155 network
+func (n Network) ChainID() (*big.Int, error) {
+	namespace, reference, err := ParseNetwork(string(n)).Parse()
+	if err != nil {
+		return nil, err
+	}
+	if namespace != "eip155" {
+		return nil, fmt.Errorf("network %s has no EVM chain ID", n)
+	}
+
+	chainID, ok := new(big.Int).SetString(reference, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid chain ID in network: %s", n)
+	}
+	return chainID, nil
+}
+
+// IsTestnet reports whether the network is a known test network
+func (n Network) IsTestnet() bool {
+	return testnets[ParseNetwork(string(n))]
+}
+
+
+/* universal-crypto-mcp © nicholas */
\ No newline at end of file
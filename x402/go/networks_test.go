@@ -0,0 +1,104 @@
+/*
+ * ═══════════════════════════════════════════════════════════════
+ *  universal-crypto-mcp | nichxbt
+ *  ID: 14938
+ * ═══════════════════════════════════════════════════════════════
+ */
+
+package x402
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseNetwork(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Network
+	}{
+		{"base", NetworkBase},
+		{"Base-Sepolia", NetworkBaseSepolia},
+		{"solana-devnet", NetworkSolanaDevnet},
+		{"eip155:8453", NetworkBase},
+		{"eip155:42161", Network("eip155:42161")},
+	}
+
+	for _, tt := range tests {
+		if got := ParseNetwork(tt.input); got != tt.want {
+			t.Errorf("ParseNetwork(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNetworkChainID(t *testing.T) {
+	tests := []struct {
+		network Network
+		want    int64
+		wantErr bool
+	}{
+		{NetworkBase, 8453, false},
+		{NetworkBaseSepolia, 84532, false},
+		{Network("base"), 8453, false},
+		{Network("eip155:137"), 137, false},
+		{NetworkSolana, 0, true},
+		{Network("eip155:abc"), 0, true},
+	}
+
+	for _, tt := range tests {
+		chainID, err := tt.network.ChainID()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ChainID(%s) expected error", tt.network)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ChainID(%s) unexpected error: %v", tt.network, err)
+			continue
+		}
+		if chainID.Int64() != tt.want {
+			t.Errorf("ChainID(%s) = %d, want %d", tt.network, chainID.Int64(), tt.want)
+		}
+	}
+}
+
+func TestNetworkIsTestnet(t *testing.T) {
+	tests := []struct {
+		network Network
+		want    bool
+	}{
+		{NetworkBase, false},
+		{NetworkBaseSepolia, true},
+		{Network("base-sepolia"), true},
+		{NetworkSolana, false},
+		{NetworkSolanaDevnet, true},
+		{Network("eip155:42161"), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.network.IsTestnet(); got != tt.want {
+			t.Errorf("IsTestnet(%s) = %v, want %v", tt.network, got, tt.want)
+		}
+	}
+}
+
+func TestNetworkJSON(t *testing.T) {
+	data, err := json.Marshal(SettleResponse{Network: NetworkBaseSepolia})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Network string `json:"network"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Network != "eip155:84532" {
+		t.Errorf("Expected network eip155:84532 on the wire, got %s", decoded.Network)
+	}
+}
+
+
+/* ucm:n1ch0a8a5074 */
\ No newline at end of file
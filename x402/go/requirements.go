@@ -0,0 +1,38 @@
+/* requirements.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package x402
+
+import (
+	"github.com/coinbase/x402/go/types"
+)
+
+// NewPaymentRequirements builds V2 payment requirements to answer with in a
+// 402 response, e.g. NewPaymentRequirements(evm.SchemeExact, "eip155:8453",
+// "10000", usdcAddress, payTo). The amount is in the asset's smallest unit and
+// the timeout defaults to DefaultMaxTimeoutSeconds
+func NewPaymentRequirements(scheme string, network Network, amount, asset, payTo string) PaymentRequirements {
+	return PaymentRequirements{
+		Scheme:            scheme,
+		Network:           string(network),
+		Asset:             asset,
+		Amount:            amount,
+		PayTo:             payTo,
+		MaxTimeoutSeconds: DefaultMaxTimeoutSeconds,
+	}
+}
+
+// WithExtensionExtra returns a copy of requirements whose Extra entry for
+// extension is declaration, enriched by the extension for transportContext,
+// e.g. the http.HTTPRequestContext of the request being answered
+func WithExtensionExtra(requirements PaymentRequirements, extension types.ResourceServerExtension, declaration, transportContext interface{}) PaymentRequirements {
+	extra := make(map[string]interface{}, len(requirements.Extra)+1)
+	for key, value := range requirements.Extra {
+		extra[key] = value
+	}
+	extra[extension.Key()] = extension.EnrichDeclaration(declaration, transportContext)
+	requirements.Extra = extra
+	return requirements
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
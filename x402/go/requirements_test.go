@@ -0,0 +1,61 @@
+/* requirements_test.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package x402
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+type mockResourceServerExtension struct{}
+
+func (e *mockResourceServerExtension) Key() string {
+	return "test"
+}
+
+func (e *mockResourceServerExtension) EnrichDeclaration(declaration interface{}, transportContext interface{}) interface{} {
+	enriched := map[string]interface{}{"transport": transportContext}
+	for key, value := range declaration.(map[string]interface{}) {
+		enriched[key] = value
+	}
+	return enriched
+}
+
+func TestNewPaymentRequirementsGolden(t *testing.T) {
+	requirements := NewPaymentRequirements("exact", "eip155:8453", "10000",
+		"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", "0x209693Bc6afc0C5328bA36FaF03C514EF312287C")
+	requirements.Extra = map[string]interface{}{"name": "USD Coin", "version": "2"}
+	base := requirements
+
+	requirements = WithExtensionExtra(requirements, &mockResourceServerExtension{},
+		map[string]interface{}{"declared": true}, "GET /report")
+
+	got, err := json.Marshal(requirements)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	golden, err := os.ReadFile("testdata/payment_requirements.golden.json")
+	if err != nil {
+		t.Fatalf("Failed to read golden fixture: %v", err)
+	}
+	if !bytes.Equal(got, bytes.TrimSpace(golden)) {
+		t.Errorf("Requirements do not match the golden fixture:\ngot:  %s\nwant: %s", got, bytes.TrimSpace(golden))
+	}
+
+	if _, ok := base.Extra["test"]; ok {
+		t.Error("Expected WithExtensionExtra not to modify the original Extra")
+	}
+
+	var decoded PaymentRequirements
+	if err := json.Unmarshal(golden, &decoded); err != nil {
+		t.Fatalf("Failed to decode golden fixture: %v", err)
+	}
+	if decoded.Scheme != "exact" || Network(decoded.Network) != "eip155:8453" || decoded.MaxTimeoutSeconds != DefaultMaxTimeoutSeconds {
+		t.Errorf("Unexpected decoded requirements: %+v", decoded)
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
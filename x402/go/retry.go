@@ -0,0 +1,102 @@
+/* retry.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package x402
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// ============================================================================
+// Retry Policy
+// ============================================================================
+
+// RetryPolicy controls how idempotent facilitator calls, such as verifying a
+// signed payment, are retried after transient failures. Requests for the paid
+// resource itself are never retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, 1 or less disables retries
+	MaxAttempts int
+	// BaseDelay is the delay before the second attempt, doubled for every
+	// further attempt
+	BaseDelay time.Duration
+	// Jitter randomizes each delay by up to this fraction of it (0.2 is ±20%)
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the retry policy of clients without WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, Jitter: 0.2}
+
+// NoRetry disables retries
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// RetryError is returned when a call still failed after being retried
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+// Error implements the error interface
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s (after %d attempts)", e.Err.Error(), e.Attempts)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Do calls fn until it succeeds, fails with an error retryable rejects, or
+// MaxAttempts is reached. Once a retry was scheduled, the last error is
+// returned as *RetryError, also when ctx is done while waiting
+func (p RetryPolicy) Do(ctx context.Context, retryable func(error) bool, fn func(ctx context.Context) error) error {
+	delay := p.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt >= p.MaxAttempts || !retryable(err) {
+			if attempt > 1 {
+				return &RetryError{Attempts: attempt, Err: err}
+			}
+			return err
+		}
+
+		timer := time.NewTimer(p.jitter(delay))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Attempts: attempt, Err: err}
+		}
+		delay *= 2
+	}
+}
+
+// jitter randomizes delay by up to ±Jitter of it
+func (p RetryPolicy) jitter(delay time.Duration) time.Duration {
+	if p.Jitter <= 0 || delay <= 0 {
+		return delay
+	}
+	spread := float64(delay) * p.Jitter
+	return delay + time.Duration(spread*(2*rand.Float64()-1))
+}
+
+// WithRetryPolicy sets how idempotent facilitator calls are retried
+// The default is DefaultRetryPolicy, NoRetry disables retries
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *x402Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// RetryPolicy returns the client's retry policy
+func (c *x402Client) RetryPolicy() RetryPolicy {
+	return c.retryPolicy
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -0,0 +1,94 @@
+/* retry_test.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package x402
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+func isTransient(err error) bool {
+	return errors.Is(err, errTransient)
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, Jitter: 0.5}
+	ctx := context.Background()
+
+	calls := 0
+	err := policy.Do(ctx, isTransient, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("Expected success on attempt 3, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = policy.Do(ctx, isTransient, func(context.Context) error {
+		calls++
+		return errTransient
+	})
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 || !errors.Is(err, errTransient) {
+		t.Fatalf("Expected RetryError after 3 attempts, got %v", err)
+	}
+	if err.Error() != "transient (after 3 attempts)" {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+
+	// Permanent errors are not retried
+	permanent := errors.New("permanent")
+	calls = 0
+	err = policy.Do(ctx, isTransient, func(context.Context) error {
+		calls++
+		return permanent
+	})
+	if err != permanent || calls != 1 {
+		t.Errorf("Expected the permanent error after 1 call, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = NoRetry.Do(ctx, isTransient, func(context.Context) error {
+		calls++
+		return errTransient
+	})
+	if err != errTransient || calls != 1 {
+		t.Errorf("Expected NoRetry to make a single attempt, got %v after %d calls", err, calls)
+	}
+}
+
+func TestRetryPolicyDoContextDone(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := policy.Do(ctx, isTransient, func(context.Context) error {
+		calls++
+		return errTransient
+	})
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 1 || calls != 1 {
+		t.Errorf("Expected to stop waiting when ctx is done, got %v after %d calls", err, calls)
+	}
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	if got := Newx402Client().RetryPolicy(); got != DefaultRetryPolicy {
+		t.Errorf("Expected DefaultRetryPolicy, got %+v", got)
+	}
+	if got := Newx402Client(WithRetryPolicy(NoRetry)).RetryPolicy(); got != NoRetry {
+		t.Errorf("Expected NoRetry, got %+v", got)
+	}
+}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
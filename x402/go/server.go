@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -164,6 +165,8 @@ func (s *x402ResourceServer) Register(network Network, schemeServer SchemeNetwor
 	return s
 }
 
+// RegisterExtension registers an extension whose declaration EnrichExtensions
+// enriches, replacing any extension registered under the same key
 func (s *x402ResourceServer) RegisterExtension(extension types.ResourceServerExtension) *x402ResourceServer {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -172,6 +175,46 @@ func (s *x402ResourceServer) RegisterExtension(extension types.ResourceServerExt
 	return s
 }
 
+// EnrichExtensions returns a copy of the extension declarations of a 402
+// response, with the entry of each registered extension set to the result of
+// its EnrichDeclaration for transportContext. Extensions are called in key
+// order with their declaration, nil when undeclared, and a nil result leaves
+// the entry unset
+func (s *x402ResourceServer) EnrichExtensions(declarations map[string]interface{}, transportContext interface{}) map[string]interface{} {
+	s.mu.RLock()
+	keys := make([]string, 0, len(s.registeredExtensions))
+	for key := range s.registeredExtensions {
+		keys = append(keys, key)
+	}
+	extensions := make([]types.ResourceServerExtension, 0, len(keys))
+	sort.Strings(keys)
+	for _, key := range keys {
+		extensions = append(extensions, s.registeredExtensions[key])
+	}
+	s.mu.RUnlock()
+
+	if len(extensions) == 0 {
+		return declarations
+	}
+
+	enriched := make(map[string]interface{}, len(declarations)+len(extensions))
+	for key, declaration := range declarations {
+		enriched[key] = declaration
+	}
+	for _, extension := range extensions {
+		key := extension.Key()
+		if declaration := extension.EnrichDeclaration(enriched[key], transportContext); declaration != nil {
+			enriched[key] = declaration
+		} else {
+			delete(enriched, key)
+		}
+	}
+	if len(enriched) == 0 {
+		return nil
+	}
+	return enriched
+}
+
 // ============================================================================
 // Hook Registration Methods (Chainable)
 // ============================================================================
@@ -253,7 +296,7 @@ func (s *x402ResourceServer) BuildPaymentRequirements(
 	// Apply default timeout if not specified
 	maxTimeout := config.MaxTimeoutSeconds
 	if maxTimeout == 0 {
-		maxTimeout = 60 // Default to 60 seconds
+		maxTimeout = DefaultMaxTimeoutSeconds
 	}
 
 	// Build base requirements
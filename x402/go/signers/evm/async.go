@@ -0,0 +1,136 @@
+// ucm:0.14.9.3:@nic
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	x402evm "github.com/coinbase/x402/go/mechanisms/evm"
+)
+
+// DefaultSignTimeout is how long AsyncSigner waits for a signature by default.
+const DefaultSignTimeout = 2 * time.Minute
+
+// ErrSignTimeout is returned when an AsyncSigner gets no signature in time.
+var ErrSignTimeout = errors.New("timed out waiting for signature")
+
+// SignRequest is an EIP-712 signing request emitted by AsyncSigner.
+// The receiver signs it out of process (e.g. in a connected wallet) and
+// answers with Respond.
+type SignRequest struct {
+	Domain      x402evm.TypedDataDomain
+	Types       map[string][]x402evm.TypedDataField
+	PrimaryType string
+	Message     map[string]interface{}
+
+	once   sync.Once
+	result chan signResult
+}
+
+type signResult struct {
+	signature []byte
+	err       error
+}
+
+// Respond delivers the signature, or the reason signing failed, to the waiting
+// SignTypedData call. Only the first call has an effect, and it never blocks,
+// even when the request already timed out.
+func (r *SignRequest) Respond(signature []byte, err error) {
+	r.once.Do(func() {
+		r.result <- signResult{signature: signature, err: err}
+	})
+}
+
+// AsyncSigner implements x402evm.ClientEvmSigner for keys held outside the
+// process, such as a WalletConnect session or a separate signing service.
+// SignTypedData emits a SignRequest on Requests and waits for its response.
+type AsyncSigner struct {
+	address  string
+	timeout  time.Duration
+	requests chan *SignRequest
+}
+
+// NewAsyncSigner creates an async signer for address.
+//
+// Args:
+//
+//	address: Ethereum address of the external key
+//	timeout: How long to wait for each signature, DefaultSignTimeout when zero
+//
+// Example:
+//
+//	signer := evm.NewAsyncSigner(wallet.Address(), 30*time.Second)
+//	go func() {
+//	    for req := range signer.Requests() {
+//	        req.Respond(wallet.SignTypedData(req.Domain, req.Types, req.PrimaryType, req.Message))
+//	    }
+//	}()
+func NewAsyncSigner(address string, timeout time.Duration) *AsyncSigner {
+	if timeout <= 0 {
+		timeout = DefaultSignTimeout
+	}
+	return &AsyncSigner{
+		address:  address,
+		timeout:  timeout,
+		requests: make(chan *SignRequest),
+	}
+}
+
+// Requests returns the channel sign requests are emitted on.
+func (s *AsyncSigner) Requests() <-chan *SignRequest {
+	return s.requests
+}
+
+// Address returns the Ethereum address of the external key.
+func (s *AsyncSigner) Address() string {
+	return s.address
+}
+
+// SignTypedData emits a SignRequest and waits for its response.
+// It fails with ErrSignTimeout when no one picks up the request or answers it
+// within the signer's timeout, and with ctx's error when ctx is done first.
+func (s *AsyncSigner) SignTypedData(
+	ctx context.Context,
+	domain x402evm.TypedDataDomain,
+	types map[string][]x402evm.TypedDataField,
+	primaryType string,
+	message map[string]interface{},
+) ([]byte, error) {
+	req := &SignRequest{
+		Domain:      domain,
+		Types:       types,
+		PrimaryType: primaryType,
+		Message:     message,
+		result:      make(chan signResult, 1),
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case s.requests <- req:
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: no receiver for %s sign request after %s", ErrSignTimeout, primaryType, s.timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-req.result:
+		if res.err != nil {
+			return nil, fmt.Errorf("external signer failed: %w", res.err)
+		}
+		return res.signature, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: no %s signature after %s", ErrSignTimeout, primaryType, s.timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+
+/* ucm:n1ch2abfa956 */
\ No newline at end of file
@@ -0,0 +1,114 @@
+// ucm:6e696368-786274-4d43-5000-000000000000:nich
+
+package evm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	x402evm "github.com/coinbase/x402/go/mechanisms/evm"
+)
+
+var _ x402evm.ClientEvmSigner = (*AsyncSigner)(nil)
+
+func asyncTestTypedData() (x402evm.TypedDataDomain, map[string][]x402evm.TypedDataField, map[string]interface{}) {
+	domain := x402evm.TypedDataDomain{
+		Name:              "USD Coin",
+		Version:           "2",
+		ChainID:           big.NewInt(84532),
+		VerifyingContract: "0x036CbD53842c5426634e7929541eC2318f3dCF7e",
+	}
+	types := map[string][]x402evm.TypedDataField{
+		"Mail": {{Name: "contents", Type: "string"}},
+	}
+	message := map[string]interface{}{"contents": "hello"}
+	return domain, types, message
+}
+
+func TestAsyncSigner_DelayedResponse(t *testing.T) {
+	wallet, err := NewClientSignerFromPrivateKey(testPrivateKeyHex)
+	if err != nil {
+		t.Fatalf("NewClientSignerFromPrivateKey() failed: %v", err)
+	}
+	signer := NewAsyncSigner(wallet.Address(), time.Second)
+
+	go func() {
+		req := <-signer.Requests()
+		time.Sleep(50 * time.Millisecond)
+		req.Respond(wallet.SignTypedData(context.Background(), req.Domain, req.Types, req.PrimaryType, req.Message))
+	}()
+
+	domain, types, message := asyncTestTypedData()
+	signature, err := signer.SignTypedData(context.Background(), domain, types, "Mail", message)
+	if err != nil {
+		t.Fatalf("SignTypedData() failed: %v", err)
+	}
+
+	want, err := wallet.SignTypedData(context.Background(), domain, types, "Mail", message)
+	if err != nil {
+		t.Fatalf("SignTypedData() failed: %v", err)
+	}
+	if !bytes.Equal(signature, want) {
+		t.Errorf("SignTypedData() = %x, want %x", signature, want)
+	}
+	if signer.Address() != wallet.Address() {
+		t.Errorf("Address() = %v, want %v", signer.Address(), wallet.Address())
+	}
+}
+
+func TestAsyncSigner_Timeout(t *testing.T) {
+	signer := NewAsyncSigner("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", 50*time.Millisecond)
+
+	// The wallet picks up the request but never answers.
+	picked := make(chan *SignRequest, 1)
+	go func() {
+		picked <- <-signer.Requests()
+	}()
+
+	domain, types, message := asyncTestTypedData()
+	_, err := signer.SignTypedData(context.Background(), domain, types, "Mail", message)
+	if !errors.Is(err, ErrSignTimeout) {
+		t.Fatalf("SignTypedData() error = %v, want ErrSignTimeout", err)
+	}
+	if !strings.Contains(err.Error(), "no Mail signature after 50ms") {
+		t.Errorf("SignTypedData() error = %q, want it to name the request and timeout", err)
+	}
+
+	// A late response must not block the wallet.
+	(<-picked).Respond([]byte{1}, nil)
+
+	// Nobody listening at all times out as well.
+	_, err = signer.SignTypedData(context.Background(), domain, types, "Mail", message)
+	if !errors.Is(err, ErrSignTimeout) {
+		t.Fatalf("SignTypedData() without receiver error = %v, want ErrSignTimeout", err)
+	}
+}
+
+func TestAsyncSigner_Errors(t *testing.T) {
+	signer := NewAsyncSigner("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", time.Second)
+	domain, types, message := asyncTestTypedData()
+
+	rejected := errors.New("user rejected")
+	go func() {
+		(<-signer.Requests()).Respond(nil, rejected)
+	}()
+	_, err := signer.SignTypedData(context.Background(), domain, types, "Mail", message)
+	if !errors.Is(err, rejected) {
+		t.Errorf("SignTypedData() error = %v, want %v", err, rejected)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = signer.SignTypedData(ctx, domain, types, "Mail", message)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("SignTypedData() error = %v, want context.Canceled", err)
+	}
+}
+
+
+/* ucm:n1ch2abfa956 */
\ No newline at end of file
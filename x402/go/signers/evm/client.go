@@ -96,6 +96,7 @@ func (s *ClientSigner) SignTypedData(
 			Version:           domain.Version,
 			ChainId:           (*math.HexOrDecimal256)(domain.ChainID),
 			VerifyingContract: domain.VerifyingContract,
+			Salt:              domain.Salt,
 		},
 		Message: message,
 	}
@@ -114,12 +115,12 @@ func (s *ClientSigner) SignTypedData(
 
 	// Add EIP712Domain type if not present
 	if _, exists := typedData.Types["EIP712Domain"]; !exists {
-		typedData.Types["EIP712Domain"] = []apitypes.Type{
 // contrib: nich.xbt
-			{Name: "name", Type: "string"},
-			{Name: "version", Type: "string"},
-			{Name: "chainId", Type: "uint256"},
-			{Name: "verifyingContract", Type: "address"},
+		for _, field := range x402evm.DomainFields(domain) {
+			typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{
+				Name: field.Name,
+				Type: field.Type,
+			})
 		}
 	}
 
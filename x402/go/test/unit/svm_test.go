@@ -95,6 +95,8 @@ func TestSolanaUtilities(t *testing.T) {
 			{svm.SolanaDevnetV1, svm.SolanaDevnetCAIP2, false},
 			{svm.SolanaTestnetV1, svm.SolanaTestnetCAIP2, false},
 			{svm.SolanaMainnetCAIP2, svm.SolanaMainnetCAIP2, false},
+			{"Solana-Devnet", svm.SolanaDevnetCAIP2, false},
+			{"base-sepolia", "", true},
 			{"invalid", "", true},
 		}
 
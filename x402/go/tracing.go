@@ -0,0 +1,62 @@
+/* tracing.go | nirholas/universal-crypto-mcp | 1493814938 */
+
+package x402
+
+import "context"
+
+// ============================================================================
+// Tracing
+// ============================================================================
+
+// Span names used by the x402 client
+const (
+	SpanParseChallenge = "x402.parse_challenge"
+	SpanSign           = "x402.sign"
+	SpanSettle         = "x402.settle"
+)
+
+// Span attribute keys set by the x402 client
+const (
+	AttributeScheme  = "x402.scheme"
+	AttributeNetwork = "x402.network"
+	AttributeAmount  = "x402.amount"
+	AttributeVersion = "x402.version"
+	AttributeTxHash  = "x402.tx_hash"
+)
+
+// Tracer starts spans around payment steps
+// It mirrors the shape of an OpenTelemetry tracer so an adapter is a thin
+// wrapper, without this package depending on OpenTelemetry
+type Tracer interface {
+	// Start starts a span named name as a child of any span in ctx
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced operation
+type Span interface {
+	// SetAttribute attaches a key/value attribute to the span
+	SetAttribute(key string, value interface{})
+
+	// RecordError marks the span as failed with err
+	RecordError(err error)
+
+	// End completes the span
+	End()
+}
+
+// NoopTracer is a Tracer whose spans do nothing
+type NoopTracer struct{}
+
+// Start returns ctx unchanged with a no-op span
+func (NoopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
+
+
+/* universal-crypto-mcp © nirholas */
\ No newline at end of file
@@ -101,6 +101,22 @@ type SettleResponse struct {
 	Payer       string  `json:"payer,omitempty"`
 	Transaction string  `json:"transaction"`
 	Network     Network `json:"network"`
+
+	// Splits reports each leg of a split payment, Success is only set when all legs settled
+	Splits []SettleLeg `json:"splits,omitempty"`
+
+	// AccessWindowSeconds is for how long the resource server accepts this
+	// payment again for the same resource, 0 when it grants no access window
+	AccessWindowSeconds int64 `json:"accessWindowSeconds,omitempty"`
+}
+
+// SettleLeg reports the settlement of one recipient/amount leg of a split payment
+type SettleLeg struct {
+	PayTo       string `json:"payTo"`
+	Amount      string `json:"amount"`
+	Success     bool   `json:"success"`
+	Transaction string `json:"transaction,omitempty"`
+	ErrorReason string `json:"errorReason,omitempty"`
 }
 
 // ResourceConfig defines payment configuration for a protected resource
@@ -183,11 +199,6 @@ func DeepEqual(a, b interface{}) bool {
 	return string(aNormJSON) == string(bNormJSON)
 }
 
-// ParseNetwork parses a network string into Network type
-func ParseNetwork(s string) Network {
-	return Network(s)
-}
-
 // IsWildcardNetwork checks if network is a wildcard pattern
 func IsWildcardNetwork(network Network) bool {
 	return strings.HasSuffix(string(network), ":*")